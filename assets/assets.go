@@ -1,3 +1,4 @@
 // assets in locale and templates folders are converted into Go source code with go-bindata
 // the data.go file in this package is auto generated through the generate-debug and generate-prod tasks in the Makefile
+// files in the data folder are embedded directly, see embed.go
 package assets
@@ -0,0 +1,20 @@
+package assets
+
+import _ "embed"
+
+// WelshAreaNames is a csv of the English and Welsh names of the nations, English regions and Welsh local authorities and
+// constituencies, as the population API only provides English names
+//
+//go:embed data/welsh-area-names.csv
+var WelshAreaNames []byte
+
+// VariableTopics is a csv of variable ids with the id and English and Welsh names of their topic, as the population API does not group variables by topic
+//
+//go:embed data/variable-topics.csv
+var VariableTopics []byte
+
+// GeographyRules is the default json of the area types and the lowest and highest area types allowed per population type and per dataset,
+// used when no rules file is configured
+//
+//go:embed data/geography-rules.json
+var GeographyRules []byte
@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Cache is a concurrency safe store of values which expire after a time to live, holding at most size values
+type Cache[T any] struct {
+	mu    sync.RWMutex
+	ttl   time.Duration
+	size  int
+	items map[string]item[T]
+	now   func() time.Time
+}
+
+type item[T any] struct {
+	value   T
+	expires time.Time
+}
+
+// New creates a cache of at most size values which expire after the time to live, nothing is stored when
+// either is not positive
+func New[T any](ttl time.Duration, size int) *Cache[T] {
+	return &Cache[T]{
+		ttl:   ttl,
+		size:  size,
+		items: map[string]item[T]{},
+		now:   time.Now,
+	}
+}
+
+// Get returns the value stored for the key when it has not expired
+func (c *Cache[T]) Get(key string) (T, bool) {
+	c.mu.RLock()
+	i, ok := c.items[key]
+	c.mu.RUnlock()
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	if !c.now().Before(i.expires) {
+		c.mu.Lock()
+		if current, ok := c.items[key]; ok && !c.now().Before(current.expires) {
+			delete(c.items, key)
+		}
+		c.mu.Unlock()
+		var zero T
+		return zero, false
+	}
+	return i.value, true
+}
+
+// Set stores the value for the key until the time to live has passed. When the cache is full the expired
+// values are removed, followed by the value closest to expiring if none have.
+func (c *Cache[T]) Set(key string, value T) {
+	if c.ttl <= 0 || c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.items[key]; !ok && len(c.items) >= c.size {
+		c.evict()
+	}
+	c.items[key] = item[T]{
+		value:   value,
+		expires: c.now().Add(c.ttl),
+	}
+}
+
+// evict removes the expired values, or the value closest to expiring when none have expired
+func (c *Cache[T]) evict() {
+	now := c.now()
+	var oldest string
+	var oldestExpires time.Time
+	for key, i := range c.items {
+		if !now.Before(i.expires) {
+			delete(c.items, key)
+			continue
+		}
+		if oldest == "" || i.expires.Before(oldestExpires) {
+			oldest, oldestExpires = key, i.expires
+		}
+	}
+	if len(c.items) >= c.size {
+		delete(c.items, oldest)
+	}
+}
@@ -0,0 +1,73 @@
+package cache
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCache(t *testing.T) {
+	Convey("Given a cache", t, func() {
+		now := time.Date(2023, 3, 28, 9, 0, 0, 0, time.UTC)
+		c := New[[]string](time.Minute, 2)
+		c.now = func() time.Time { return now }
+
+		Convey("When a value is stored", func() {
+			c.Set("UR", []string{"ltla"})
+
+			Convey("Then it is returned before it expires", func() {
+				now = now.Add(59 * time.Second)
+				value, ok := c.Get("UR")
+				So(ok, ShouldBeTrue)
+				So(value, ShouldResemble, []string{"ltla"})
+			})
+
+			Convey("Then it is not returned once it has expired", func() {
+				now = now.Add(time.Minute)
+				_, ok := c.Get("UR")
+				So(ok, ShouldBeFalse)
+				So(c.items, ShouldBeEmpty)
+			})
+
+			Convey("Then other keys are not returned", func() {
+				_, ok := c.Get("HH")
+				So(ok, ShouldBeFalse)
+			})
+
+			Convey("Then the value closest to expiring is removed when the cache is full", func() {
+				now = now.Add(time.Second)
+				c.Set("HH", []string{"ctry"})
+				now = now.Add(time.Second)
+				c.Set("UR_HH", []string{"nat"})
+
+				So(c.items, ShouldHaveLength, 2)
+				_, ok := c.Get("UR")
+				So(ok, ShouldBeFalse)
+				value, ok := c.Get("HH")
+				So(ok, ShouldBeTrue)
+				So(value, ShouldResemble, []string{"ctry"})
+			})
+
+			Convey("Then expired values are removed when the cache is full", func() {
+				c.Set("HH", []string{"ctry"})
+				now = now.Add(time.Minute)
+				c.Set("UR_HH", []string{"nat"})
+
+				So(c.items, ShouldHaveLength, 1)
+				_, ok := c.Get("UR_HH")
+				So(ok, ShouldBeTrue)
+			})
+		})
+	})
+
+	Convey("Given a cache without a time to live", t, func() {
+		c := New[int](0, 10)
+		c.Set("UR", 1)
+
+		Convey("Then nothing is stored", func() {
+			_, ok := c.Get("UR")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
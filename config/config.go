@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"time"
 
 	"github.com/kelseyhightower/envconfig"
@@ -12,15 +13,22 @@ type Config struct {
 	BindAddr                    string        `envconfig:"BIND_ADDR"`
 	Debug                       bool          `envconfig:"DEBUG"`
 	DefaultMaximumSearchResults int           `envconfig:"DEFAULT_MAXIMUM_SEARCH_RESULTS"`
+	EnableFilterHistory         bool          `envconfig:"ENABLE_FILTER_HISTORY"`
 	EnableMultivariate          bool          `envconfig:"ENABLE_MULTIVARIATE"`
 	FeedbackAPIURL              string        `envconfig:"FEEDBACK_API_URL"`
+	FilterHistorySecret         string        `envconfig:"FILTER_HISTORY_SECRET" json:"-"`
+	GeographyRulesPath          string        `envconfig:"GEOGRAPHY_RULES_PATH"`
 	GracefulShutdownTimeout     time.Duration `envconfig:"GRACEFUL_SHUTDOWN_TIMEOUT"`
 	HealthCheckInterval         time.Duration `envconfig:"HEALTHCHECK_INTERVAL"`
 	HealthCheckCriticalTimeout  time.Duration `envconfig:"HEALTHCHECK_CRITICAL_TIMEOUT"`
+	MaxFilterHistory            int           `envconfig:"MAX_FILTER_HISTORY"`
+	MaxSelectAllAreas           int           `envconfig:"MAX_SELECT_ALL_AREAS"`
 	OTBatchTimeout              time.Duration `encconfig:"OTEL_BATCH_TIMEOUT"`
 	OTServiceName               string        `envconfig:"OTEL_SERVICE_NAME"`
 	OTExporterOTLPEndpoint      string        `envconfig:"OTEL_EXPORTER_OTLP_ENDPOINT"`
 	PatternLibraryAssetsPath    string        `envconfig:"PATTERN_LIBRARY_ASSETS_PATH"`
+	PopulationCacheSize         int           `envconfig:"POPULATION_CACHE_SIZE"`
+	PopulationCacheTTL          time.Duration `envconfig:"POPULATION_CACHE_TTL"`
 	SiteDomain                  string        `envconfig:"SITE_DOMAIN"`
 	SupportedLanguages          []string      `envconfig:"SUPPORTED_LANGUAGES"`
 }
@@ -35,6 +43,10 @@ func Get() (*Config, error) {
 		return nil, err
 	}
 
+	if err = cfg.validate(); err != nil {
+		return nil, err
+	}
+
 	if cfg.Debug {
 		cfg.PatternLibraryAssetsPath = "http://localhost:9002/dist/assets"
 	} else {
@@ -44,6 +56,15 @@ func Get() (*Config, error) {
 	return cfg, nil
 }
 
+// validate checks the config values which depend on each other
+func (c *Config) validate() error {
+	// an empty secret would allow anyone to sign a filter history
+	if c.EnableFilterHistory && c.FilterHistorySecret == "" {
+		return errors.New("FILTER_HISTORY_SECRET is required when ENABLE_FILTER_HISTORY is true")
+	}
+	return nil
+}
+
 func get() (*Config, error) {
 	if cfg != nil {
 		return cfg, nil
@@ -54,14 +75,21 @@ func get() (*Config, error) {
 		BindAddr:                    "localhost:20100",
 		Debug:                       false,
 		DefaultMaximumSearchResults: 50,
+		EnableFilterHistory:         false,
 		EnableMultivariate:          false,
 		FeedbackAPIURL:              "http://localhost:23200/v1/feedback",
+		FilterHistorySecret:         "",
+		GeographyRulesPath:          "",
 		GracefulShutdownTimeout:     5 * time.Second,
 		HealthCheckInterval:         30 * time.Second,
 		HealthCheckCriticalTimeout:  90 * time.Second,
+		MaxFilterHistory:            10,
+		MaxSelectAllAreas:           500,
 		OTBatchTimeout:              5 * time.Second,
 		OTExporterOTLPEndpoint:      "localhost:4317",
 		OTServiceName:               "dp-frontend-filter-flex-dataset",
+		PopulationCacheSize:         1000,
+		PopulationCacheTTL:          time.Hour,
 		SiteDomain:                  "localhost",
 		SupportedLanguages:          []string{"en", "cy"},
 	}
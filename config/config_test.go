@@ -22,6 +22,7 @@ func TestConfig(t *testing.T) {
 			Convey("Then the values should be set to the expected defaults", func() {
 				So(cfg.Debug, ShouldBeFalse)
 				So(cfg.EnableMultivariate, ShouldBeFalse)
+				So(cfg.EnableFilterHistory, ShouldBeFalse)
 				So(cfg.APIRouterURL, ShouldEqual, "http://localhost:23200/v1")
 				So(cfg.BindAddr, ShouldEqual, "localhost:20100")
 				So(cfg.DefaultMaximumSearchResults, ShouldEqual, 50)
@@ -31,6 +32,12 @@ func TestConfig(t *testing.T) {
 				So(cfg.GracefulShutdownTimeout, ShouldEqual, 5*time.Second)
 				So(cfg.HealthCheckInterval, ShouldEqual, 30*time.Second)
 				So(cfg.HealthCheckCriticalTimeout, ShouldEqual, 90*time.Second)
+				So(cfg.FilterHistorySecret, ShouldEqual, "")
+				So(cfg.GeographyRulesPath, ShouldEqual, "")
+				So(cfg.MaxFilterHistory, ShouldEqual, 10)
+				So(cfg.MaxSelectAllAreas, ShouldEqual, 500)
+				So(cfg.PopulationCacheSize, ShouldEqual, 1000)
+				So(cfg.PopulationCacheTTL, ShouldEqual, time.Hour)
 			})
 
 			Convey("Then a second call to config should return the same config", func() {
@@ -40,4 +47,41 @@ func TestConfig(t *testing.T) {
 			})
 		})
 	})
+	Convey("Given the filter history is enabled without a secret", t, func() {
+		os.Clearenv()
+		cfg = nil
+		os.Setenv("ENABLE_FILTER_HISTORY", "true")
+		defer func() {
+			os.Clearenv()
+			cfg = nil
+		}()
+
+		Convey("When the config is retrieved", func() {
+			_, err := Get()
+
+			Convey("Then a validation error is returned", func() {
+				So(err, ShouldNotBeNil)
+			})
+		})
+	})
+
+	Convey("Given the filter history is enabled with a secret", t, func() {
+		os.Clearenv()
+		cfg = nil
+		os.Setenv("ENABLE_FILTER_HISTORY", "true")
+		os.Setenv("FILTER_HISTORY_SECRET", "secret")
+		defer func() {
+			os.Clearenv()
+			cfg = nil
+		}()
+
+		Convey("When the config is retrieved", func() {
+			c, err := Get()
+
+			Convey("Then there is no error", func() {
+				So(err, ShouldBeNil)
+				So(c.EnableFilterHistory, ShouldBeTrue)
+			})
+		})
+	})
 }
@@ -0,0 +1,123 @@
+package geography
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+)
+
+// Rule limits the area types which can be chosen to those between the highest and lowest area types
+type Rule struct {
+	Lowest     string `json:"lowest"`
+	Highest    string `json:"highest"`
+	CustomOnly bool   `json:"custom_only"`
+}
+
+// Rules represents the area types which rules can refer to, ordered from the highest to the lowest geography, and the rules
+// per population type and per dataset, a dataset rule takes precedence over a population type rule
+type Rules struct {
+	AreaTypes       []string        `json:"area_types"`
+	PopulationTypes map[string]Rule `json:"population_types"`
+	Datasets        map[string]Rule `json:"datasets"`
+}
+
+// Load loads and validates the rules file at the path, the embedded rules are used when the path is empty
+func Load(path string) (*Rules, error) {
+	data := assets.GeographyRules
+	if path != "" {
+		var err error
+		data, err = os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read geography rules: %w", err)
+		}
+	}
+	return parse(data)
+}
+
+// Limits returns the lowest and highest area types allowed for the dataset and population type, which are empty when not limited
+func (r *Rules) Limits(datasetID, populationType string, isCustom bool) (lowest, highest string) {
+	for _, rule := range []Rule{r.PopulationTypes[populationType], r.Datasets[datasetID]} {
+		if rule.CustomOnly && !isCustom {
+			continue
+		}
+		if rule.Lowest != "" {
+			lowest = rule.Lowest
+		}
+		if rule.Highest != "" {
+			highest = rule.Highest
+		}
+	}
+	return lowest, highest
+}
+
+// Range returns the area types between the highest and lowest area types, given area types ordered from the highest to the lowest
+// geography, limits which are not in the given area types are ignored
+func Range(areaTypes []string, lowest, highest string) []string {
+	allowed := areaTypes
+	for i, areaType := range allowed {
+		if highest != "" && areaType == highest {
+			allowed = allowed[i:]
+			break
+		}
+	}
+	for i, areaType := range allowed {
+		if lowest != "" && areaType == lowest {
+			allowed = allowed[:i+1]
+			break
+		}
+	}
+	return allowed
+}
+
+// parse reads and validates a json of rules
+func parse(data []byte) (*Rules, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var r Rules
+	if err := dec.Decode(&r); err != nil {
+		return nil, fmt.Errorf("failed to parse geography rules: %w", err)
+	}
+	if err := r.validate(); err != nil {
+		return nil, err
+	}
+	return &r, nil
+}
+
+// validate checks the area types are listed once and every rule refers to them, with the lowest area type not above the highest
+func (r *Rules) validate() error {
+	if len(r.AreaTypes) == 0 {
+		return errors.New("invalid geography rules: missing area types")
+	}
+	for i, areaType := range r.AreaTypes {
+		if r.index(areaType) != i {
+			return fmt.Errorf("invalid geography rules: duplicate area type %q", areaType)
+		}
+	}
+	for kind, set := range map[string]map[string]Rule{"population type": r.PopulationTypes, "dataset": r.Datasets} {
+		for id, rule := range set {
+			for _, areaType := range []string{rule.Lowest, rule.Highest} {
+				if areaType != "" && r.index(areaType) < 0 {
+					return fmt.Errorf("invalid geography rule for %s %q: unknown area type %q", kind, id, areaType)
+				}
+			}
+			if rule.Lowest != "" && rule.Highest != "" && r.index(rule.Lowest) < r.index(rule.Highest) {
+				return fmt.Errorf("invalid geography rule for %s %q: lowest area type %q is above highest area type %q", kind, id, rule.Lowest, rule.Highest)
+			}
+		}
+	}
+	return nil
+}
+
+// index returns the position of the area type in the area types of the rules, or -1 when it is not listed
+func (r *Rules) index(areaType string) int {
+	for i, known := range r.AreaTypes {
+		if known == areaType {
+			return i
+		}
+	}
+	return -1
+}
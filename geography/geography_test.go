@@ -0,0 +1,110 @@
+package geography
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestEmbeddedRules(t *testing.T) {
+	Convey("The embedded rules are valid", t, func() {
+		_, err := parse(assets.GeographyRules)
+		So(err, ShouldBeNil)
+	})
+
+	Convey("Given the embedded rules", t, func() {
+		r, err := Load("")
+		So(err, ShouldBeNil)
+
+		Convey("Then the area types are listed from the highest to the lowest geography", func() {
+			So(r.AreaTypes[0], ShouldEqual, "nat")
+			So(r.AreaTypes[len(r.AreaTypes)-1], ShouldEqual, "oa")
+		})
+
+		Convey("Then the lowest geography of custom filters of the population type is overridden", func() {
+			lowest, highest := r.Limits("dataset", "UR_CE", true)
+			So(lowest, ShouldEqual, "msoa")
+			So(highest, ShouldBeEmpty)
+		})
+
+		Convey("Then filters which are not custom are not limited", func() {
+			lowest, _ := r.Limits("dataset", "UR_CE", false)
+			So(lowest, ShouldBeEmpty)
+		})
+
+		Convey("Then other population types are not limited", func() {
+			lowest, highest := r.Limits("dataset", "UR", true)
+			So(lowest, ShouldBeEmpty)
+			So(highest, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		return path
+	}
+
+	Convey("Given a rules file", t, func() {
+		path := write("rules.json", `{
+			"area_types": ["rgn", "ltla", "msoa", "street"],
+			"population_types": {"UR": {"lowest": "ltla", "highest": "rgn"}},
+			"datasets": {"TS008": {"lowest": "street"}}
+		}`)
+		r, err := Load(path)
+		So(err, ShouldBeNil)
+
+		Convey("Then the area types are read from the file", func() {
+			So(r.AreaTypes, ShouldResemble, []string{"rgn", "ltla", "msoa", "street"})
+		})
+
+		Convey("Then the population type rule is applied", func() {
+			lowest, highest := r.Limits("TS009", "UR", false)
+			So(lowest, ShouldEqual, "ltla")
+			So(highest, ShouldEqual, "rgn")
+		})
+
+		Convey("Then the dataset rule takes precedence", func() {
+			lowest, highest := r.Limits("TS008", "UR", false)
+			So(lowest, ShouldEqual, "street")
+			So(highest, ShouldEqual, "rgn")
+		})
+	})
+
+	Convey("Invalid rules files return an error", t, func() {
+		for _, path := range []string{
+			filepath.Join(dir, "missing.json"),
+			write("unknown-field.json", `{"area_types": ["rgn"], "areas": {}}`),
+			write("missing-area-types.json", `{"datasets": {}}`),
+			write("duplicate-area-type.json", `{"area_types": ["rgn", "ltla", "rgn"]}`),
+			write("unknown-area-type.json", `{"area_types": ["rgn", "ltla"], "datasets": {"TS008": {"lowest": "street"}}}`),
+			write("inverted.json", `{"area_types": ["rgn", "ltla"], "population_types": {"UR": {"lowest": "rgn", "highest": "ltla"}}}`),
+		} {
+			_, err := Load(path)
+			So(err, ShouldNotBeNil)
+		}
+	})
+}
+
+func TestRange(t *testing.T) {
+	areaTypes := []string{"nat", "ctry", "rgn", "ltla", "msoa", "oa"}
+
+	Convey("Area types between the highest and lowest area types are returned", t, func() {
+		So(Range(areaTypes, "msoa", "rgn"), ShouldResemble, []string{"rgn", "ltla", "msoa"})
+		So(Range(areaTypes, "msoa", ""), ShouldResemble, []string{"nat", "ctry", "rgn", "ltla", "msoa"})
+		So(Range(areaTypes, "", "ltla"), ShouldResemble, []string{"ltla", "msoa", "oa"})
+		So(Range(areaTypes, "", ""), ShouldResemble, areaTypes)
+	})
+
+	Convey("Limits which are not in the area types are ignored", t, func() {
+		So(Range(areaTypes, "lsoa", "utla"), ShouldResemble, areaTypes)
+	})
+}
@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+)
+
+// exampleAreaCount is the number of example areas shown for each area type
+const exampleAreaCount = 3
+
+// getAreaTypeDetails gets example areas and parent area types of the area types of the population type,
+// the details of published data are cached per population type
+func (f *FilterFlex) getAreaTypeDetails(ctx context.Context, accessToken, collectionID, populationType string, areaTypes []population.AreaType) (map[string]mapper.AreaTypeDetails, error) {
+	cacheable := isCacheable(accessToken, collectionID)
+	if details, ok := f.AreaTypeDetails.Get(populationType); ok && cacheable {
+		return details, nil
+	}
+
+	details := make(map[string]mapper.AreaTypeDetails, len(areaTypes))
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAreaRequests)
+	for _, areaType := range areaTypes {
+		wg.Add(1)
+		go func(areaTypeID string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			detail, err := f.getAreaTypeDetail(ctx, accessToken, populationType, areaTypeID)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+				}
+				return
+			}
+			details[areaTypeID] = detail
+		}(areaType.ID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if cacheable {
+		f.AreaTypeDetails.Set(populationType, details)
+	}
+	return details, nil
+}
+
+// getAreaTypeDetail gets the first areas and the parent area types of an area type
+func (f *FilterFlex) getAreaTypeDetail(ctx context.Context, accessToken, populationType, areaTypeID string) (mapper.AreaTypeDetails, error) {
+	areas, err := f.PopulationClient.GetAreas(ctx, population.GetAreasInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: exampleAreaCount,
+		},
+		PopulationType: populationType,
+		AreaTypeID:     areaTypeID,
+	})
+	if err != nil {
+		return mapper.AreaTypeDetails{}, fmt.Errorf("failed to get areas of area type %s: %w", areaTypeID, err)
+	}
+
+	parents, err := f.PopulationClient.GetAreaTypeParents(ctx, population.GetAreaTypeParentsInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: populationType,
+		AreaTypeID:     areaTypeID,
+	})
+	if err != nil {
+		return mapper.AreaTypeDetails{}, fmt.Errorf("failed to get parents of area type %s: %w", areaTypeID, err)
+	}
+
+	examples := areas.Areas
+	if len(examples) > exampleAreaCount {
+		examples = examples[:exampleAreaCount]
+	}
+	return mapper.AreaTypeDetails{
+		Examples: examples,
+		Parents:  parents.AreaTypes,
+	}, nil
+}
@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAreaTypeDetails(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := context.Background()
+	areaTypes := []population.AreaType{{ID: "ltla"}, {ID: "ctry"}}
+
+	Convey("Given the area types of a population type", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+
+		Convey("When the details are requested twice", func() {
+			for _, areaType := range areaTypes {
+				mockPc.EXPECT().
+					GetAreas(gomock.Any(), population.GetAreasInput{
+						PaginationParams: population.PaginationParams{Limit: exampleAreaCount},
+						PopulationType:   "UR",
+						AreaTypeID:       areaType.ID,
+					}).
+					Return(population.GetAreasResponse{Areas: []population.Area{{ID: areaType.ID + "1", Label: "Example"}}}, nil)
+			}
+			mockPc.EXPECT().
+				GetAreaTypeParents(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input population.GetAreaTypeParentsInput) (population.GetAreaTypeParentsResponse, error) {
+					if input.AreaTypeID == "ltla" {
+						return population.GetAreaTypeParentsResponse{AreaTypes: []population.AreaType{{ID: "ctry"}}}, nil
+					}
+					return population.GetAreaTypeParentsResponse{}, nil
+				}).
+				Times(2)
+
+			details, err := ff.getAreaTypeDetails(ctx, "", "", "UR", areaTypes)
+			So(err, ShouldBeNil)
+			cached, err := ff.getAreaTypeDetails(ctx, "", "", "UR", areaTypes)
+			So(err, ShouldBeNil)
+
+			Convey("Then the details are fetched once and cached for the population type", func() {
+				So(details["ltla"], ShouldResemble, mapper.AreaTypeDetails{
+					Examples: []population.Area{{ID: "ltla1", Label: "Example"}},
+					Parents:  []population.AreaType{{ID: "ctry"}},
+				})
+				So(details["ctry"].Parents, ShouldBeEmpty)
+				So(cached, ShouldResemble, details)
+			})
+		})
+
+		Convey("When the details are requested for a collection", func() {
+			mockPc.EXPECT().
+				GetAreas(gomock.Any(), gomock.Any()).
+				Return(population.GetAreasResponse{}, nil).
+				Times(4)
+			mockPc.EXPECT().
+				GetAreaTypeParents(gomock.Any(), gomock.Any()).
+				Return(population.GetAreaTypeParentsResponse{}, nil).
+				Times(4)
+
+			_, err := ff.getAreaTypeDetails(ctx, "token", "collection", "UR", areaTypes)
+			So(err, ShouldBeNil)
+			_, err = ff.getAreaTypeDetails(ctx, "token", "collection", "UR", areaTypes)
+			So(err, ShouldBeNil)
+
+			Convey("Then the details are fetched for each request and not cached", func() {
+				_, ok := ff.AreaTypeDetails.Get("UR")
+				So(ok, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the population client responds with an error", func() {
+			mockPc.EXPECT().
+				GetAreas(gomock.Any(), gomock.Any()).
+				Return(population.GetAreasResponse{}, errors.New("internal error")).
+				Times(2)
+
+			_, err := ff.getAreaTypeDetails(ctx, "", "", "UR", areaTypes)
+
+			Convey("Then the error is returned and nothing is cached", func() {
+				So(err, ShouldNotBeNil)
+				_, ok := ff.AreaTypeDetails.Get("UR")
+				So(ok, ShouldBeFalse)
+			})
+		})
+	})
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// maxConcurrentAreaRequests limits the number of area requests made at the same time
+const maxConcurrentAreaRequests = 5
+
+// coverageMapping represents the coverage of an area type dimension mapped onto another area type,
+// the kept areas are areas of the parent area type when it is set
+type coverageMapping struct {
+	Parent string
+	Kept   []population.Area
+	Lost   []population.Area
+}
+
+// options returns the ids of the kept areas
+func (c coverageMapping) options() []string {
+	options := make([]string, 0, len(c.Kept))
+	for _, area := range c.Kept {
+		options = append(options, area.ID)
+	}
+	return options
+}
+
+// areaTypeChange represents a change of the area type of a filter with the coverage which is kept
+type areaTypeChange struct {
+	Filter   filter.Model
+	Current  filter.Dimension
+	AreaType population.AreaType
+	Coverage coverageMapping
+}
+
+// AreaTypeReview Handler
+func (f *FilterFlex) AreaTypeReview() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		areaTypeReview(w, req, f, collectionID, accessToken, lang)
+	})
+}
+
+func areaTypeReview(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken, lang string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	dimensionName := vars["name"]
+	areaTypeID := req.URL.Query().Get("dimension")
+
+	logData := log.Data{
+		"filter_id": filterID,
+		"dimension": dimensionName,
+		"area_type": areaTypeID,
+	}
+
+	eb, serviceMsg, err := getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	// log zebedee error but don't set a server error
+	if err != nil {
+		log.Error(ctx, "unable to get homepage content", err, log.Data{"homepage_content": err})
+	}
+
+	change, err := f.getAreaTypeChange(ctx, accessToken, collectionID, filterID, dimensionName, areaTypeID)
+	if err != nil {
+		log.Error(ctx, "failed to get area type change", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
+	m.SetDatasetContext(f.getDatasetContext(ctx, accessToken, collectionID, change.Filter.Dataset, helpers.IsBoolPtr(change.Filter.Custom)))
+	review := m.CreateAreaTypeReview(dimensionName, change.AreaType, change.Coverage.Parent, change.Coverage.Kept, change.Coverage.Lost)
+	f.Render.BuildPage(w, review, "area-type-review")
+}
+
+// ChangeAreaType Handler
+func (f *FilterFlex) ChangeAreaType() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		changeAreaType(w, req, f, collectionID, accessToken)
+	})
+}
+
+func changeAreaType(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	dimensionName := vars["name"]
+
+	logData := log.Data{
+		"filter_id": filterID,
+		"dimension": dimensionName,
+	}
+
+	if err := req.ParseForm(); err != nil {
+		log.Error(ctx, "failed to parse change area type form", err, logData)
+		setStatusCode(req, w, fmt.Errorf("error parsing form: %w", err))
+		return
+	}
+
+	change, err := f.getAreaTypeChange(ctx, accessToken, collectionID, filterID, dimensionName, req.FormValue("dimension"))
+	if err != nil {
+		log.Error(ctx, "failed to get area type change", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	dimension := filter.Dimension{
+		Name:                 change.AreaType.ID,
+		ID:                   change.AreaType.ID,
+		IsAreaType:           helpers.ToBoolPtr(true),
+		Options:              change.Coverage.options(),
+		FilterByParent:       change.Coverage.Parent,
+		QualityStatementText: change.Current.QualityStatementText,
+		QualitySummaryURL:    change.Current.QualitySummaryURL,
+	}
+	if _, _, err := f.FilterClient.UpdateDimensions(ctx, accessToken, "", collectionID, filterID, dimensionName, "", dimension); err != nil {
+		log.Error(ctx, "error updating filter dimension", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	f.recordFilterHistory(w, req, filterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
+}
+
+// getAreaTypeChange validates the change of the area type dimension of the filter to an allowed area type and maps its coverage
+func (f *FilterFlex) getAreaTypeChange(ctx context.Context, accessToken, collectionID, filterID, dimensionName, areaTypeID string) (areaTypeChange, error) {
+	if areaTypeID == "" {
+		return areaTypeChange{}, &clientErr{errors.New("missing required value 'dimension'")}
+	}
+
+	currentFilter, _, err := f.FilterClient.GetJobState(ctx, accessToken, "", "", collectionID, filterID)
+	if err != nil {
+		return areaTypeChange{}, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	current, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterID, dimensionName)
+	if err != nil {
+		return areaTypeChange{}, fmt.Errorf("failed to find dimension in filter: %w", err)
+	}
+	if !isAreaType(current) {
+		return areaTypeChange{}, &clientErr{errors.New("area type can only be changed for the area type dimension")}
+	}
+
+	areaTypes, err := f.allowedAreaTypes(ctx, accessToken, collectionID, currentFilter)
+	if err != nil {
+		return areaTypeChange{}, err
+	}
+	change := areaTypeChange{Filter: currentFilter, Current: current}
+	for _, areaType := range areaTypes {
+		if areaType.ID == areaTypeID {
+			change.AreaType = areaType
+		}
+	}
+	if change.AreaType.ID == "" {
+		return areaTypeChange{}, &clientErr{fmt.Errorf("area type %q is not allowed", areaTypeID)}
+	}
+
+	options, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterID, dimensionName)
+	if err != nil {
+		return areaTypeChange{}, fmt.Errorf("failed to get options for dimension: %w", err)
+	}
+	change.Coverage, err = f.mapCoverage(ctx, accessToken, currentFilter.PopulationType, current, options, areaTypeID)
+	if err != nil {
+		return areaTypeChange{}, err
+	}
+	return change, nil
+}
+
+// mapCoverage maps the coverage of the area type dimension onto the area type. The coverage is kept when it is of the area type
+// or of a parent area type of the area type, otherwise only the areas which are also areas of the area type are kept
+func (f *FilterFlex) mapCoverage(ctx context.Context, accessToken, populationType string, current filter.Dimension, options []string, areaTypeID string) (coverageMapping, error) {
+	if len(options) == 0 {
+		return coverageMapping{}, nil
+	}
+
+	coverageType := current.ID
+	if current.FilterByParent != "" {
+		coverageType = current.FilterByParent
+	}
+	areas, err := f.getAreasByID(ctx, accessToken, populationType, coverageType, options)
+	if err != nil {
+		return coverageMapping{}, err
+	}
+	for i := range areas {
+		if areas[i].ID == "" {
+			areas[i] = population.Area{ID: options[i], Label: options[i]}
+		}
+	}
+	if coverageType == areaTypeID {
+		return coverageMapping{Kept: areas}, nil
+	}
+
+	parents, err := f.PopulationClient.GetAreaTypeParents(ctx, population.GetAreaTypeParentsInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: populationType,
+		AreaTypeID:     areaTypeID,
+	})
+	if err != nil {
+		return coverageMapping{}, fmt.Errorf("failed to get area type parents: %w", err)
+	}
+	for _, parent := range parents.AreaTypes {
+		if parent.ID == coverageType {
+			return coverageMapping{Parent: coverageType, Kept: areas}, nil
+		}
+	}
+
+	// the parents endpoints of the population API only list parent area types and count the areas within parent areas, so the
+	// parent of an area can not be resolved. Areas are kept when they are also areas of the area type, e.g. unitary authorities
+	// which are both lower and upper tier local authorities, and the rest are reviewed as lost
+	matches, err := f.getAreasByID(ctx, accessToken, populationType, areaTypeID, options)
+	if err != nil {
+		return coverageMapping{}, err
+	}
+	var mapping coverageMapping
+	for i, match := range matches {
+		if match.ID == "" {
+			mapping.Lost = append(mapping.Lost, areas[i])
+			continue
+		}
+		mapping.Kept = append(mapping.Kept, match)
+	}
+	return mapping, nil
+}
+
+// getAreasByID gets the areas of the area type in the order of the ids, areas which are not found have an empty id
+func (f *FilterFlex) getAreasByID(ctx context.Context, accessToken, populationType, areaTypeID string, ids []string) ([]population.Area, error) {
+	areas := make([]population.Area, len(ids))
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentAreaRequests)
+	for i, id := range ids {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			area, err := f.PopulationClient.GetArea(ctx, population.GetAreaInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: accessToken,
+				},
+				PopulationType: populationType,
+				AreaType:       areaTypeID,
+				Area:           id,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if cErr, ok := err.(ClientError); ok && cErr.Code() == http.StatusNotFound {
+					return
+				}
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get area %s: %w", id, err)
+				}
+				return
+			}
+			areas[i] = area.Area
+		}(i, id)
+	}
+	wg.Wait()
+
+	return areas, firstErr
+}
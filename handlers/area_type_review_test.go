@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAreaTypeReviewHandlers(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	const filterID = "1234"
+	const dimensionName = "ltla"
+
+	areaTypes := population.GetAreaTypesResponse{
+		AreaTypes: []population.AreaType{
+			{ID: "msoa", Label: "MSOA", Hierarchy_Order: 300},
+			{ID: "ctry", Label: "Country", Hierarchy_Order: 900},
+			{ID: "ltla", Label: "Lower tier local authorities", Hierarchy_Order: 500},
+			{ID: "utla", Label: "Upper tier local authorities", Hierarchy_Order: 600},
+		},
+	}
+	areas := map[string]map[string]string{
+		"ctry": {"E92000001": "England"},
+		"ltla": {"E06000001": "Hartlepool", "E07000008": "Cambridge"},
+		"utla": {"E06000001": "Hartlepool"},
+	}
+
+	expectChange := func(mockFc *MockFilterClient, mockPc *MockPopulationClient, mockDc *MockDatasetClient, current filter.Dimension, options ...string) {
+		mockFc.
+			EXPECT().
+			GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+			Return(filter.Model{PopulationType: "UR"}, "", nil)
+		mockFc.
+			EXPECT().
+			GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+			Return(current, "", nil)
+		mockPc.
+			EXPECT().
+			GetAreaTypes(gomock.Any(), gomock.Any()).
+			Return(areaTypes, nil)
+		mockDc.
+			EXPECT().
+			GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(dataset.Version{}, nil)
+		opts := filter.DimensionOptions{}
+		for _, opt := range options {
+			opts.Items = append(opts.Items, filter.DimensionOption{Option: opt})
+		}
+		mockFc.
+			EXPECT().
+			GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any()).
+			Return(opts, "", nil)
+		mockPc.
+			EXPECT().
+			GetArea(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input population.GetAreaInput) (population.GetAreaResponse, error) {
+				label, ok := areas[input.AreaType][input.Area]
+				if !ok {
+					return population.GetAreaResponse{}, &testCliError{}
+				}
+				return population.GetAreaResponse{Area: population.Area{ID: input.Area, Label: label, AreaType: input.AreaType}}, nil
+			}).
+			AnyTimes()
+	}
+	expectParents := func(mockPc *MockPopulationClient, areaTypeID string, parents ...string) {
+		resp := population.GetAreaTypeParentsResponse{}
+		for _, parent := range parents {
+			resp.AreaTypes = append(resp.AreaTypes, population.AreaType{ID: parent})
+		}
+		mockPc.
+			EXPECT().
+			GetAreaTypeParents(gomock.Any(), population.GetAreaTypeParentsInput{
+				PaginationParams: population.PaginationParams{Limit: 1000},
+				PopulationType:   "UR",
+				AreaTypeID:       areaTypeID,
+			}).
+			Return(resp, nil)
+	}
+	newZebedeeClient := func() *MockZebedeeClient {
+		mockZc := NewMockZebedeeClient(mockCtrl)
+		mockZc.
+			EXPECT().
+			GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(zebedee.HomepageContent{}, nil)
+		return mockZc
+	}
+
+	Convey("Area type review", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockDc := NewMockDatasetClient(mockCtrl)
+
+		Convey("Given a coverage with areas which are not areas of the new area type", func() {
+			current := filter.Dimension{Name: dimensionName, ID: dimensionName, IsAreaType: helpers.ToBoolPtr(true)}
+			expectChange(mockFc, mockPc, mockDc, current, "E06000001", "E07000008")
+			expectParents(mockPc, "utla", "ctry")
+			expectDatasetContext(mockDc)
+
+			var review model.AreaTypeReview
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.
+				EXPECT().
+				BuildPage(gomock.Any(), gomock.Any(), "area-type-review").
+				Do(func(_ interface{}, page interface{}, _ string) {
+					review = page.(model.AreaTypeReview)
+				})
+
+			ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, newZebedeeClient(), testGeography, cfg)
+			w := runAreaTypeReview(http.MethodGet, "dimension=utla", filterID, dimensionName, nil, ff.AreaTypeReview())
+
+			Convey("Then the kept and lost areas are reviewed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(review.Dimension, ShouldEqual, "utla")
+				So(review.AreaType, ShouldEqual, "Upper tier local authorities")
+				So(review.KeptAreas, ShouldResemble, []string{"Hartlepool"})
+				So(review.LostAreas, ShouldResemble, []string{"Cambridge (E07000008)"})
+				So(review.IsParent, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given a coverage of a parent area type of the new area type", func() {
+			current := filter.Dimension{Name: dimensionName, ID: dimensionName, IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "ctry", QualitySummaryURL: "/quality"}
+			expectChange(mockFc, mockPc, mockDc, current, "E92000001")
+			expectParents(mockPc, "msoa", "ltla", "ctry")
+			mockFc.
+				EXPECT().
+				UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any(), filter.Dimension{
+					Name:              "msoa",
+					ID:                "msoa",
+					IsAreaType:        helpers.ToBoolPtr(true),
+					Options:           []string{"E92000001"},
+					FilterByParent:    "ctry",
+					QualitySummaryURL: "/quality",
+				}).
+				Return(filter.Dimension{}, "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runAreaTypeReview(http.MethodPost, "", filterID, dimensionName, url.Values{"dimension": {"msoa"}}, ff.ChangeAreaType())
+
+			Convey("Then the area type is changed keeping the coverage as parent areas", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions", filterID))
+			})
+		})
+
+		Convey("Given a coverage of the new area type", func() {
+			current := filter.Dimension{Name: dimensionName, ID: dimensionName, IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "utla"}
+			expectChange(mockFc, mockPc, mockDc, current, "E06000001")
+			mockFc.
+				EXPECT().
+				UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any(), filter.Dimension{
+					Name:       "utla",
+					ID:         "utla",
+					IsAreaType: helpers.ToBoolPtr(true),
+					Options:    []string{"E06000001"},
+				}).
+				Return(filter.Dimension{}, "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runAreaTypeReview(http.MethodPost, "", filterID, dimensionName, url.Values{"dimension": {"utla"}}, ff.ChangeAreaType())
+
+			Convey("Then the area type is changed keeping the coverage as areas of the area type", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+			})
+		})
+
+		Convey("Given an area type which is not offered by the selector", func() {
+			mockFc.
+				EXPECT().
+				GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+				Return(filter.Model{PopulationType: "UR"}, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+				Return(filter.Dimension{Name: dimensionName, ID: dimensionName, IsAreaType: helpers.ToBoolPtr(true)}, "", nil)
+			mockPc.
+				EXPECT().
+				GetAreaTypes(gomock.Any(), gomock.Any()).
+				Return(areaTypes, nil)
+			mockDc.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(dataset.Version{}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runAreaTypeReview(http.MethodPost, "", filterID, dimensionName, url.Values{"dimension": {"street"}}, ff.ChangeAreaType())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("Given a dimension which is not an area type", func() {
+			mockFc.
+				EXPECT().
+				GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+				Return(filter.Model{PopulationType: "UR"}, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+				Return(filter.Dimension{Name: dimensionName, ID: dimensionName, IsAreaType: helpers.ToBoolPtr(false)}, "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runAreaTypeReview(http.MethodPost, "", filterID, dimensionName, url.Values{"dimension": {"utla"}}, ff.ChangeAreaType())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func runAreaTypeReview(method, query, filterID, dimensionName string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	encodedFormData := formData.Encode()
+	target := fmt.Sprintf("/filters/%s/dimensions/%s/review", filterID, dimensionName)
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(encodedFormData))
+	if formData != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+	}
+
+	w := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/dimensions/{name}/review", handler)
+	router.ServeHTTP(w, req)
+
+	return w
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getAreaTypesBlockedCount returns the blocked area count of the variables of the filter at each of the area types.
+// Changing area type may not keep the coverage, so area types are evaluated against the default coverage.
+// Errors are logged and the affected area type skipped, as the counts are not required to render the page.
+func (f *FilterFlex) getAreaTypesBlockedCount(ctx context.Context, accessToken, collectionID, filterID, populationType, areaTypeID string, areaTypes []population.AreaType) map[string]*cantabular.GetBlockedAreaCountResult {
+	if len(areaTypes) == 0 {
+		return nil
+	}
+	logData := log.Data{
+		"filter_id":       filterID,
+		"population_type": populationType,
+	}
+
+	filterDims, _, err := f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
+	if err != nil {
+		log.Error(ctx, "failed to get filter dimensions for area type blocked counts", err, logData)
+		return nil
+	}
+	dimIds := make([]string, 0, len(filterDims.Items))
+	for _, dim := range filterDims.Items {
+		dimIds = append(dimIds, dim.ID)
+	}
+	// without variables every area is available at every area type
+	if len(dimIds) < 2 {
+		return nil
+	}
+
+	cacheable := isCacheable(accessToken, collectionID)
+	results := make(map[string]*cantabular.GetBlockedAreaCountResult, len(areaTypes))
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSDCRequests)
+	for _, areaType := range areaTypes {
+		variables := replaceVariable(dimIds, areaTypeID, areaType.ID)
+		key := populationType + ":" + strings.Join(variables, ",")
+		if sdc, ok := f.BlockedAreaCounts.Get(key); ok && cacheable {
+			mu.Lock()
+			results[areaType.ID] = &sdc
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(id, key string, variables []string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			sdc, err := f.getBlockedAreaCount(ctx, accessToken, populationType, id, "", variables, nil)
+			if err != nil {
+				log.Error(ctx, "failed to get blocked area count for area type", err, log.Data{
+					"population_type": populationType,
+					"variables":       variables,
+					"area_type_id":    id,
+				})
+				return
+			}
+			if cacheable {
+				f.BlockedAreaCounts.Set(key, *sdc)
+			}
+			mu.Lock()
+			results[id] = sdc
+			mu.Unlock()
+		}(areaType.ID, key, variables)
+	}
+	wg.Wait()
+
+	return results
+}
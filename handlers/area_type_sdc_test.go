@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetAreaTypesBlockedCount(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := context.Background()
+	const filterID = "1234"
+	areaTypes := []population.AreaType{{ID: "ctry"}, {ID: "ltla"}, {ID: "msoa"}}
+
+	Convey("Given a filter with variables", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockFc.
+			EXPECT().
+			GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).
+			Return(filter.Dimensions{Items: []filter.Dimension{{Name: "ltla", ID: "ltla"}, {Name: "sex", ID: "sex"}}}, "", nil).
+			Times(2)
+		mockPc := NewMockPopulationClient(mockCtrl)
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+
+		Convey("When the blocked counts of the area types are requested twice", func() {
+			var mu sync.Mutex
+			var filters []population.Filter
+			mockPc.
+				EXPECT().
+				GetBlockedAreaCount(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ context.Context, input population.GetBlockedAreaCountInput) (*cantabular.GetBlockedAreaCountResult, error) {
+					mu.Lock()
+					filters = append(filters, input.Filter)
+					mu.Unlock()
+					switch input.Variables[0] {
+					case "ctry":
+						return &cantabular.GetBlockedAreaCountResult{Passed: 2, Total: 2}, nil
+					case "ltla":
+						return &cantabular.GetBlockedAreaCountResult{Passed: 300, Blocked: 31, Total: 331}, nil
+					default:
+						return nil, errors.New("internal error")
+					}
+				}).
+				Times(4)
+
+			results := ff.getAreaTypesBlockedCount(ctx, "", "", filterID, "UR", "ltla", areaTypes)
+			cached := ff.getAreaTypesBlockedCount(ctx, "", "", filterID, "UR", "ltla", areaTypes)
+
+			Convey("Then the counts are returned for the area types with the variables of the filter", func() {
+				So(results["ctry"], ShouldResemble, &cantabular.GetBlockedAreaCountResult{Passed: 2, Total: 2})
+				So(results["ltla"].Blocked, ShouldEqual, 31)
+			})
+
+			Convey("Then the area types are evaluated against the default coverage", func() {
+				for _, f := range filters {
+					So(f, ShouldResemble, population.Filter{Codes: []string{"K04000001"}, Variable: "nat"})
+				}
+			})
+
+			Convey("Then area types with errors are skipped and not cached", func() {
+				So(results, ShouldNotContainKey, "msoa")
+				So(cached, ShouldResemble, results)
+			})
+		})
+	})
+
+	Convey("Given a filter without variables", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockFc.
+			EXPECT().
+			GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).
+			Return(filter.Dimensions{Items: []filter.Dimension{{Name: "ltla", ID: "ltla"}}}, "", nil)
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+
+		Convey("Then no blocked counts are requested", func() {
+			So(ff.getAreaTypesBlockedCount(ctx, "", "", filterID, "UR", "ltla", areaTypes), ShouldBeNil)
+		})
+	})
+}
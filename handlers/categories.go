@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// CategorySelector Handler
+func (f *FilterFlex) CategorySelector() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		categorySelector(w, req, f, collectionID, accessToken, lang)
+	})
+}
+
+func categorySelector(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken, lang string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	dimensionName := vars["name"]
+
+	logData := log.Data{
+		"filter_id": filterID,
+		"dimension": dimensionName,
+	}
+
+	eb, serviceMsg, err := getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	// log zebedee error but don't set a server error
+	if err != nil {
+		log.Error(ctx, "unable to get homepage content", err, log.Data{"homepage_content": err})
+	}
+
+	currentFilter, filterDimension, categories, err := f.getVariableCategories(ctx, accessToken, collectionID, filterID, dimensionName)
+	if err != nil {
+		log.Error(ctx, "failed to get categories of dimension", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// the dataset context is got alongside the selected options to avoid another sequential round trip
+	var datasetContext mapper.DatasetContext
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		datasetContext = f.getDatasetContext(ctx, accessToken, collectionID, currentFilter.Dataset, helpers.IsBoolPtr(currentFilter.Custom))
+	}()
+	// the options of a variable are the chosen subset of its categories, which are empty when every category is included
+	subset, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterID, dimensionName)
+	wg.Wait()
+	if err != nil {
+		log.Error(ctx, "failed to get options for dimension", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
+	m.SetDatasetContext(datasetContext)
+	selector := m.CreateCategorySelector(filterDimension.Label, categories, subset)
+	f.Render.BuildPage(w, selector, "categories")
+}
+
+// UpdateCategories Handler
+func (f *FilterFlex) UpdateCategories() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		updateCategories(w, req, f, collectionID, accessToken)
+	})
+}
+
+func updateCategories(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	dimensionName := vars["name"]
+
+	logData := log.Data{
+		"filter_id": filterID,
+		"dimension": dimensionName,
+	}
+
+	if err := req.ParseForm(); err != nil {
+		log.Error(ctx, "failed to parse update categories form", err, logData)
+		setStatusCode(req, w, fmt.Errorf("error parsing form: %w", err))
+		return
+	}
+	selected := req.Form["categories"]
+	if len(selected) == 0 {
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions/%s/categories?error=true", filterID, dimensionName), http.StatusMovedPermanently)
+		return
+	}
+
+	_, _, categories, err := f.getVariableCategories(ctx, accessToken, collectionID, filterID, dimensionName)
+	if err != nil {
+		log.Error(ctx, "failed to get categories of dimension", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	valid := make(map[string]bool, len(categories.Categories))
+	for _, cat := range categories.Categories {
+		valid[cat.ID] = true
+	}
+	wanted := map[string]bool{}
+	for _, id := range selected {
+		if !valid[id] {
+			err := &clientErr{fmt.Errorf("invalid category %q", id)}
+			log.Error(ctx, "failed to validate categories", err, logData)
+			setStatusCode(req, w, err)
+			return
+		}
+		wanted[id] = true
+	}
+
+	current, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterID, dimensionName)
+	if err != nil {
+		log.Error(ctx, "failed to get options for dimension", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// selecting every category is the same as having no subset
+	if len(wanted) == len(valid) {
+		if len(current) > 0 {
+			if _, err := f.FilterClient.DeleteDimensionOptions(ctx, accessToken, "", collectionID, filterID, dimensionName); err != nil {
+				log.Error(ctx, "failed to delete dimension options", err, logData)
+				setStatusCode(req, w, err)
+				return
+			}
+			f.recordFilterHistory(w, req, filterID, true)
+		}
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
+		return
+	}
+
+	existing := make(map[string]bool, len(current))
+	for _, id := range current {
+		existing[id] = true
+		if wanted[id] {
+			continue
+		}
+		if _, err := f.FilterClient.RemoveDimensionValue(ctx, accessToken, "", collectionID, filterID, dimensionName, id, ""); err != nil {
+			log.Error(ctx, "failed to remove dimension value", err, log.Data{
+				"filter_id": filterID,
+				"dimension": dimensionName,
+				"option":    id,
+			})
+			setStatusCode(req, w, err)
+			return
+		}
+	}
+	for _, cat := range categories.Categories {
+		if !wanted[cat.ID] || existing[cat.ID] {
+			continue
+		}
+		if _, err := f.FilterClient.AddDimensionValue(ctx, accessToken, "", collectionID, filterID, dimensionName, cat.ID, ""); err != nil {
+			log.Error(ctx, "failed to add dimension value", err, log.Data{
+				"filter_id": filterID,
+				"dimension": dimensionName,
+				"option":    cat.ID,
+			})
+			setStatusCode(req, w, err)
+			return
+		}
+	}
+
+	f.recordFilterHistory(w, req, filterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
+}
+
+// getVariableCategories gets the filter job and a non area type dimension of the filter with the categories of its categorisation
+func (f *FilterFlex) getVariableCategories(ctx context.Context, accessToken, collectionID, filterID, dimensionName string) (*filter.Model, filter.Dimension, population.DimensionCategory, error) {
+	currentFilter, _, err := f.FilterClient.GetJobState(ctx, accessToken, "", "", collectionID, filterID)
+	if err != nil {
+		return nil, filter.Dimension{}, population.DimensionCategory{}, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	filterDimension, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterID, dimensionName)
+	if err != nil {
+		return nil, filter.Dimension{}, population.DimensionCategory{}, fmt.Errorf("failed to find dimension in filter: %w", err)
+	}
+	if isAreaType(filterDimension) {
+		return nil, filter.Dimension{}, population.DimensionCategory{}, &clientErr{errors.New("categories can only be chosen for variables")}
+	}
+
+	dimCategories, err := f.PopulationClient.GetDimensionCategories(ctx, population.GetDimensionCategoryInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: currentFilter.PopulationType,
+		Dimensions:     []string{filterDimension.ID},
+	})
+	if err != nil {
+		return nil, filter.Dimension{}, population.DimensionCategory{}, fmt.Errorf("failed to get dimension categories: %w", err)
+	}
+
+	for _, dimCategory := range dimCategories.Categories {
+		if dimCategory.Id == filterDimension.ID {
+			return &currentFilter, filterDimension, dimCategory, nil
+		}
+	}
+	return &currentFilter, filterDimension, population.DimensionCategory{Id: filterDimension.ID}, nil
+}
@@ -0,0 +1,232 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCategoriesHandlers(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	const filterID = "1234"
+	const dimensionName = "resident_age_3a"
+
+	variable := filter.Dimension{Name: dimensionName, ID: dimensionName, Label: "Age", IsAreaType: helpers.ToBoolPtr(false)}
+	categories := population.GetDimensionCategoriesResponse{
+		Categories: []population.DimensionCategory{
+			{
+				Id: dimensionName,
+				Categories: []population.DimensionCategoryItem{
+					{ID: "1", Label: "Aged 15 years and under"},
+					{ID: "2", Label: "Aged 16 to 64 years"},
+					{ID: "3", Label: "Aged 65 years and over"},
+				},
+			},
+		},
+	}
+
+	expectCategories := func(mockFc *MockFilterClient, mockPc *MockPopulationClient, dim filter.Dimension) {
+		mockFc.
+			EXPECT().
+			GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+			Return(filter.Model{PopulationType: "UR"}, "", nil)
+		mockFc.
+			EXPECT().
+			GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+			Return(dim, "", nil)
+		if isAreaType(dim) {
+			return
+		}
+		mockPc.
+			EXPECT().
+			GetDimensionCategories(gomock.Any(), population.GetDimensionCategoryInput{
+				PaginationParams: population.PaginationParams{Limit: 1000},
+				PopulationType:   "UR",
+				Dimensions:       []string{dimensionName},
+			}).
+			Return(categories, nil)
+	}
+	expectSubset := func(mockFc *MockFilterClient, subset ...string) {
+		opts := filter.DimensionOptions{}
+		for _, opt := range subset {
+			opts.Items = append(opts.Items, filter.DimensionOption{Option: opt})
+		}
+		mockFc.
+			EXPECT().
+			GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any()).
+			Return(opts, "", nil)
+	}
+
+	Convey("Category selector", t, func() {
+		Convey("Given a variable with a category subset", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			expectCategories(mockFc, mockPc, variable)
+			expectSubset(mockFc, "2")
+
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.
+				EXPECT().
+				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(zebedee.HomepageContent{}, nil)
+
+			var selector model.CategorySelector
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.
+				EXPECT().
+				BuildPage(gomock.Any(), gomock.Any(), "categories").
+				Do(func(_ interface{}, page interface{}, _ string) {
+					selector = page.(model.CategorySelector)
+				})
+
+			ff := NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, mockZc, testGeography, cfg)
+			w := runCategories(http.MethodGet, filterID, dimensionName, nil, ff.CategorySelector())
+
+			Convey("Then the categories are rendered with the subset selected", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(selector.Categories, ShouldHaveLength, 3)
+				So(selector.Categories[1].IsSelected, ShouldBeTrue)
+				So(selector.Categories[0].IsSelected, ShouldBeFalse)
+			})
+		})
+
+		Convey("Given an area type dimension", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectCategories(mockFc, nil, filter.Dimension{Name: dimensionName, IsAreaType: helpers.ToBoolPtr(true)})
+
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.
+				EXPECT().
+				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(zebedee.HomepageContent{}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := runCategories(http.MethodGet, filterID, dimensionName, nil, ff.CategorySelector())
+
+			Convey("Then the status code should be 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+
+	Convey("Update categories", t, func() {
+		Convey("Given no categories are selected", func() {
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCategories(http.MethodPost, filterID, dimensionName, url.Values{}, ff.UpdateCategories())
+
+			Convey("Then the user is redirected to the category selector with an error", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/%s/categories?error=true", filterID, dimensionName))
+			})
+		})
+
+		Convey("Given a change to the category subset", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			expectCategories(mockFc, mockPc, variable)
+			expectSubset(mockFc, "1", "2")
+			mockFc.
+				EXPECT().
+				RemoveDimensionValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, "1", gomock.Any()).
+				Return("", nil)
+			mockFc.
+				EXPECT().
+				AddDimensionValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, "3", gomock.Any()).
+				Return("", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCategories(http.MethodPost, filterID, dimensionName, url.Values{"categories": {"2", "3"}}, ff.UpdateCategories())
+
+			Convey("Then deselected categories are removed and new categories added", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions", filterID))
+			})
+		})
+
+		Convey("Given every category is selected", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			expectCategories(mockFc, mockPc, variable)
+			expectSubset(mockFc, "2")
+			mockFc.
+				EXPECT().
+				DeleteDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+				Return("", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCategories(http.MethodPost, filterID, dimensionName, url.Values{"categories": {"1", "2", "3"}}, ff.UpdateCategories())
+
+			Convey("Then the subset is removed", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+			})
+		})
+
+		Convey("Given a category which is not in the variable", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			expectCategories(mockFc, mockPc, variable)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCategories(http.MethodPost, filterID, dimensionName, url.Values{"categories": {"4"}}, ff.UpdateCategories())
+
+			Convey("Then the status code should be 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("Given the filter API fails to add a category", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			expectCategories(mockFc, mockPc, variable)
+			expectSubset(mockFc)
+			mockFc.
+				EXPECT().
+				AddDimensionValue(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, "1", gomock.Any()).
+				Return("", errors.New("internal error"))
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCategories(http.MethodPost, filterID, dimensionName, url.Values{"categories": {"1"}}, ff.UpdateCategories())
+
+			Convey("Then the status code should be 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func runCategories(method, filterID, dimensionName string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	encodedFormData := formData.Encode()
+	req := httptest.NewRequest(method, fmt.Sprintf("/filters/%s/dimensions/%s/categories", filterID, dimensionName), strings.NewReader(encodedFormData))
+	if formData != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+	}
+
+	w := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/dimensions/{name}/categories", handler)
+	router.ServeHTTP(w, req)
+
+	return w
+}
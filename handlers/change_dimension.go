@@ -1,13 +1,16 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"sync"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
@@ -17,11 +20,12 @@ import (
 // ChangeDimension Handler
 func (f *FilterFlex) ChangeDimension() http.HandlerFunc {
 	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
-		changeDimension(w, req, f.FilterClient, accessToken, collectionID)
+		changeDimension(w, req, f, accessToken, collectionID)
 	})
 }
 
-func changeDimension(w http.ResponseWriter, req *http.Request, fc FilterClient, accessToken, collectionID string) {
+func changeDimension(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	fc := f.FilterClient
 	ctx := req.Context()
 	vars := mux.Vars(req)
 	filterID := vars["filterID"]
@@ -62,6 +66,36 @@ func changeDimension(w http.ResponseWriter, req *http.Request, fc FilterClient,
 		return
 	}
 
+	isAllowed, err := f.isAllowedDimension(ctx, accessToken, collectionID, filterID, dimensionName, fd, form)
+	if err != nil {
+		log.Error(ctx, "failed to validate dimension", err, log.Data{
+			"filter_id":    filterID,
+			"dimension":    form.Dimension,
+			"is_area_type": form.IsAreaType,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+	if !isAllowed {
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions/%s?error=true&invalid=true", filterID, dimensionName), http.StatusMovedPermanently)
+		return
+	}
+
+	// changing the area type of a filter with a coverage is reviewed so compatible coverage is kept
+	if form.IsAreaType && fd.ID != form.Dimension {
+		options, err := getAllDimensionOptionIDs(ctx, fc, accessToken, collectionID, filterID, dimensionName)
+		if err != nil {
+			log.Error(ctx, "failed to get options for dimension", err, logData)
+			setStatusCode(req, w, err)
+			return
+		}
+		if len(options) > 0 {
+			q := url.Values{"dimension": []string{form.Dimension}}
+			http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions/%s/review?%s", filterID, dimensionName, q.Encode()), http.StatusMovedPermanently)
+			return
+		}
+	}
+
 	dimension := filter.Dimension{
 		Name:                 form.Dimension,
 		ID:                   form.Dimension,
@@ -76,9 +110,82 @@ func changeDimension(w http.ResponseWriter, req *http.Request, fc FilterClient,
 		return
 	}
 
+	f.recordFilterHistory(w, req, filterID, true)
 	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
 }
 
+// isAllowedDimension determines whether the posted dimension is offered by the selector, area types can only replace area types and
+// must be area types of the population type between the highest and lowest allowed geography, categorisations can only replace
+// categorisations and must be categorisations of the variable
+func (f *FilterFlex) isAllowedDimension(ctx context.Context, accessToken, collectionID, filterID, dimensionName string, fd filter.Dimension, form changeDimensionForm) (bool, error) {
+	if form.IsAreaType != isAreaType(fd) {
+		return false, nil
+	}
+
+	currentFilter, _, err := f.FilterClient.GetJobState(ctx, accessToken, "", "", collectionID, filterID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get job state: %w", err)
+	}
+
+	if !form.IsAreaType {
+		cats, err := f.PopulationClient.GetCategorisations(ctx, population.GetCategorisationsInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: accessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+			PopulationType: currentFilter.PopulationType,
+			Dimension:      dimensionName,
+		})
+		if err != nil {
+			return false, fmt.Errorf("failed to get categorisations: %w", err)
+		}
+		for _, cat := range cats.Items {
+			if cat.ID == form.Dimension {
+				return true, nil
+			}
+		}
+		return false, nil
+	}
+
+	areaTypes, err := f.allowedAreaTypes(ctx, accessToken, collectionID, currentFilter)
+	if err != nil {
+		return false, err
+	}
+	for _, areaType := range areaTypes {
+		if areaType.ID == form.Dimension {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// allowedAreaTypes returns the area types of the population type of the filter offered by the area type selector,
+// from the highest to the lowest allowed geography
+func (f *FilterFlex) allowedAreaTypes(ctx context.Context, accessToken, collectionID string, currentFilter filter.Model) ([]population.AreaType, error) {
+	areaTypes, err := f.PopulationClient.GetAreaTypes(ctx, population.GetAreaTypesInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: currentFilter.PopulationType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get population area types: %w", err)
+	}
+
+	details, err := f.DatasetClient.GetVersion(ctx, accessToken, "", "", collectionID, currentFilter.Dataset.DatasetID, currentFilter.Dataset.Edition, strconv.Itoa(currentFilter.Dataset.Version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dataset version: %w", err)
+	}
+	lowest, highest := f.geographyLimits(currentFilter, details.LowestGeography)
+
+	return rangeAreaTypes(areaTypes.AreaTypes, lowest, highest), nil
+}
+
 // changeDimensionForm represents form-data for the ChangeDimension handler.
 type changeDimensionForm struct {
 	Dimension  string
@@ -10,7 +10,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
@@ -27,15 +29,47 @@ func TestChangeDimensionHandler(t *testing.T) {
 		stubFormData.Add("is_area_type", "true")
 
 		filterClient := NewMockFilterClient(mockCtrl)
+		datasetClient := NewMockDatasetClient(mockCtrl)
+		populationClient := NewMockPopulationClient(mockCtrl)
 		ff := NewFilterFlex(
 			NewMockRenderClient(mockCtrl),
 			filterClient,
-			NewMockDatasetClient(mockCtrl),
-			NewMockPopulationClient(mockCtrl),
+			datasetClient,
+			populationClient,
 			NewMockZebedeeClient(mockCtrl),
+			testGeography,
 			cfg)
 
+		areaTypes := population.GetAreaTypesResponse{
+			AreaTypes: []population.AreaType{
+				{ID: "oa", Hierarchy_Order: 100},
+				{ID: "country", Hierarchy_Order: 900},
+				{ID: "msoa", Hierarchy_Order: 300},
+			},
+		}
+
 		Convey("Given a valid dimension", func() {
+			filterClient.
+				EXPECT().
+				GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(filter.Model{}, "", nil).
+				AnyTimes()
+			populationClient.
+				EXPECT().
+				GetAreaTypes(gomock.Any(), gomock.Any()).
+				Return(areaTypes, nil).
+				AnyTimes()
+			datasetClient.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(dataset.Version{}, nil).
+				AnyTimes()
+			filterClient.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(filter.DimensionOptions{}, "", nil).
+				AnyTimes()
+
 			Convey("When the user is redirected to the dimensions review screen", func() {
 				const filterID = "1234"
 
@@ -48,7 +82,7 @@ func TestChangeDimensionHandler(t *testing.T) {
 				filterClient.
 					EXPECT().
 					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(filter.Dimension{}, "", nil)
+					Return(filter.Dimension{IsAreaType: helpers.ToBoolPtr(true)}, "", nil)
 
 				w := runChangeDimension(filterID, "city", stubFormData, ff.ChangeDimension())
 
@@ -79,7 +113,7 @@ func TestChangeDimensionHandler(t *testing.T) {
 				filterClient.
 					EXPECT().
 					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(filter.Dimension{}, "", nil)
+					Return(filter.Dimension{IsAreaType: helpers.ToBoolPtr(true)}, "", nil)
 
 				formData := url.Values{}
 				formData.Add("dimension", newDimension)
@@ -96,7 +130,7 @@ func TestChangeDimensionHandler(t *testing.T) {
 				filterClient.
 					EXPECT().
 					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
-					Return(filter.Dimension{}, "", nil)
+					Return(filter.Dimension{IsAreaType: helpers.ToBoolPtr(true)}, "", nil)
 
 				w := runChangeDimension("test", "test", stubFormData, ff.ChangeDimension())
 
@@ -127,6 +161,175 @@ func TestChangeDimensionHandler(t *testing.T) {
 			})
 		})
 
+		Convey("Given an area type dimension with a coverage", func() {
+			const filterID = "1234"
+			const dimensionName = "geography"
+
+			filterClient.
+				EXPECT().
+				GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+				Return(filter.Dimension{ID: "msoa", IsAreaType: helpers.ToBoolPtr(true)}, "", nil)
+			filterClient.
+				EXPECT().
+				GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+				Return(filter.Model{}, "", nil)
+			populationClient.
+				EXPECT().
+				GetAreaTypes(gomock.Any(), gomock.Any()).
+				Return(areaTypes, nil)
+			datasetClient.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(dataset.Version{}, nil)
+			filterClient.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any()).
+				Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "E02000001"}}}, "", nil)
+
+			formData := url.Values{}
+			formData.Add("dimension", "country")
+			formData.Add("is_area_type", "true")
+
+			w := runChangeDimension(filterID, dimensionName, formData, ff.ChangeDimension())
+
+			Convey("Then the client is redirected to review the coverage before the area type is changed", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/%s/review?dimension=country", filterID, dimensionName))
+			})
+
+			Convey("Then the filter is not recorded in the history as nothing is saved yet", func() {
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("Given a dimension which is not offered by the selector", func() {
+			const filterID = "1234"
+
+			tests := map[string]struct {
+				job        filter.Model
+				dimension  string
+				isAreaType string
+			}{
+				"Area type which is not an area type of the population type":   {filter.Model{PopulationType: "UR"}, "street", "true"},
+				"Area type below the lowest geography of the dataset":          {filter.Model{PopulationType: "UR"}, "oa", "true"},
+				"Area type below the lowest geography of the rules":            {filter.Model{PopulationType: "UR_CE", Custom: helpers.ToBoolPtr(true)}, "oa", "true"},
+				"Categorisation which is not a categorisation of the variable": {filter.Model{PopulationType: "UR"}, "resident_age_4a", "false"},
+			}
+
+			for name, test := range tests {
+				Convey(name, func() {
+					filterClient.
+						EXPECT().
+						GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(filter.Dimension{IsAreaType: helpers.ToBoolPtr(test.isAreaType == "true")}, "", nil)
+					filterClient.
+						EXPECT().
+						GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+						Return(test.job, "", nil)
+					populationClient.
+						EXPECT().
+						GetAreaTypes(gomock.Any(), gomock.Any()).
+						Return(areaTypes, nil).
+						AnyTimes()
+					datasetClient.
+						EXPECT().
+						GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(dataset.Version{LowestGeography: "msoa"}, nil).
+						AnyTimes()
+					populationClient.
+						EXPECT().
+						GetCategorisations(gomock.Any(), gomock.Any()).
+						Return(population.GetCategorisationsResponse{Items: []population.Dimension{{ID: "resident_age_3a"}}}, nil).
+						AnyTimes()
+
+					formData := url.Values{}
+					formData.Add("dimension", test.dimension)
+					formData.Add("is_area_type", test.isAreaType)
+
+					w := runChangeDimension(filterID, "geography", formData, ff.ChangeDimension())
+
+					Convey("Then the client should be redirected to the selector with an error", func() {
+						So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+						So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/geography?error=true&invalid=true", filterID))
+					})
+				})
+			}
+
+			mismatched := map[string]struct {
+				current    filter.Dimension
+				dimension  string
+				isAreaType string
+			}{
+				"Area type replacing a variable":        {filter.Dimension{ID: "resident_age_3a", IsAreaType: helpers.ToBoolPtr(false)}, "country", "true"},
+				"Categorisation replacing an area type": {filter.Dimension{ID: "country", IsAreaType: helpers.ToBoolPtr(true)}, "resident_age_3a", "false"},
+			}
+
+			for name, test := range mismatched {
+				Convey(name, func() {
+					filterClient.
+						EXPECT().
+						GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(test.current, "", nil)
+
+					formData := url.Values{}
+					formData.Add("dimension", test.dimension)
+					formData.Add("is_area_type", test.isAreaType)
+
+					w := runChangeDimension(filterID, "geography", formData, ff.ChangeDimension())
+
+					Convey("Then the client should be redirected to the selector with an error without the filter being changed", func() {
+						So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+						So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/geography?error=true&invalid=true", filterID))
+					})
+				})
+			}
+		})
+
+		Convey("Given a categorisation of the variable", func() {
+			const filterID = "1234"
+			const dimensionName = "resident_age_6a"
+
+			filterClient.
+				EXPECT().
+				GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName).
+				Return(filter.Dimension{}, "", nil)
+			filterClient.
+				EXPECT().
+				GetJobState(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID).
+				Return(filter.Model{PopulationType: "UR"}, "", nil)
+			populationClient.
+				EXPECT().
+				GetCategorisations(gomock.Any(), population.GetCategorisationsInput{
+					PaginationParams: population.PaginationParams{Limit: 1000},
+					PopulationType:   "UR",
+					Dimension:        dimensionName,
+				}).
+				Return(population.GetCategorisationsResponse{Items: []population.Dimension{{ID: "resident_age_3a"}, {ID: "resident_age_6a"}}}, nil)
+			filterClient.
+				EXPECT().
+				UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, dimensionName, gomock.Any(), filter.Dimension{
+					Name:       "resident_age_3a",
+					ID:         "resident_age_3a",
+					IsAreaType: helpers.ToBoolPtr(false),
+				}).
+				Return(filter.Dimension{}, "", nil)
+
+			formData := url.Values{}
+			formData.Add("dimension", "resident_age_3a")
+			formData.Add("is_area_type", "false")
+
+			w := runChangeDimension(filterID, dimensionName, formData, ff.ChangeDimension())
+
+			Convey("Then the categorisation is changed", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions", filterID))
+			})
+
+			Convey("Then the filter is recorded in the history", func() {
+				So(w.Result().Cookies(), ShouldHaveLength, 1)
+			})
+		})
+
 		Convey("Given an invalid request", func() {
 			ff := NewFilterFlex(
 				NewMockRenderClient(mockCtrl),
@@ -134,6 +337,7 @@ func TestChangeDimensionHandler(t *testing.T) {
 				NewMockDatasetClient(mockCtrl),
 				NewMockPopulationClient(mockCtrl),
 				NewMockZebedeeClient(mockCtrl),
+				testGeography,
 				cfg)
 
 			filterClient.
@@ -161,6 +365,10 @@ func TestChangeDimensionHandler(t *testing.T) {
 				Convey("And the status code should be 301", func() {
 					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
 				})
+
+				Convey("And the filter is not recorded in the history", func() {
+					So(w.Result().Cookies(), ShouldBeEmpty)
+				})
 			})
 
 			Convey("When the request is missing the hidden required form values", func() {
@@ -10,6 +10,7 @@ import (
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 
@@ -26,11 +27,24 @@ const (
 	Search
 	Continue
 	ParentCoverageSearch
-	CoverageDefault = "default"
-	NameSearch      = "name-search"
-	ParentSearch    = "parent-search"
+	AddAll
+	CoverageDefault   = "default"
+	NameSearch        = "name-search"
+	ParentSearch      = "parent-search"
+	Browse            = "browse"
+	SelectAllPage     = "page"
+	SelectAllMatching = "matching"
 )
 
+// optionsPageSize is the number of dimension options requested from the filter API at once
+const optionsPageSize = 500
+
+// isCacheable returns true when population data can be shared between requests. Requests with a user token or
+// collection may see unpublished data, so they are not cached
+func isCacheable(accessToken, collectionID string) bool {
+	return accessToken == "" && collectionID == ""
+}
+
 // getZebContent is a helper function that returns the homepage content required to map the emergency banner and service message
 func getZebContent(ctx context.Context, zc ZebedeeClient, userAuthToken, collectionID, lang string) (zebedee.EmergencyBanner, string, error) {
 	hpc, err := zc.GetHomepageContent(ctx, userAuthToken, collectionID, lang, "/")
@@ -63,11 +77,46 @@ func isMultivariateDataset(ctx context.Context, dc DatasetClient, accessToken, c
 	if err != nil {
 		return false, fmt.Errorf("failed to get dataset: %w", err)
 	}
+	return isMultivariateType(d), nil
+}
+
+// isMultivariateType determines whether the dataset is a multivariate dataset type
+func isMultivariateType(d dataset.DatasetDetails) bool {
+	return strings.Contains(d.Type, "multivariate")
+}
+
+// getAllDimensionOptions gets every option of a filter dimension, a page at a time
+func getAllDimensionOptions(ctx context.Context, fc FilterClient, accessToken, collectionID, filterID, name string) (filter.DimensionOptions, error) {
+	var all filter.DimensionOptions
+	for {
+		opts, _, err := fc.GetDimensionOptions(ctx, accessToken, "", collectionID, filterID, name, &filter.QueryParams{
+			Offset: len(all.Items),
+			Limit:  optionsPageSize,
+		})
+		if err != nil {
+			return filter.DimensionOptions{}, err
+		}
+		all.Items = append(all.Items, opts.Items...)
+		all.TotalCount = opts.TotalCount
+		if len(opts.Items) == 0 || len(all.Items) >= opts.TotalCount {
+			all.Count = len(all.Items)
+			return all, nil
+		}
+	}
+}
+
+// getAllDimensionOptionIDs gets the ids of every option of a filter dimension
+func getAllDimensionOptionIDs(ctx context.Context, fc FilterClient, accessToken, collectionID, filterID, name string) ([]string, error) {
+	opts, err := getAllDimensionOptions(ctx, fc, accessToken, collectionID, filterID, name)
+	if err != nil {
+		return nil, err
+	}
 
-	if strings.Contains(d.Type, "multivariate") {
-		return true, nil
+	ids := make([]string, 0, len(opts.Items))
+	for _, opt := range opts.Items {
+		ids = append(ids, opt.Option)
 	}
-	return false, nil
+	return ids, nil
 }
 
 func setStatusCode(req *http.Request, w http.ResponseWriter, err error) {
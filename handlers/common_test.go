@@ -1,12 +1,18 @@
 package handlers
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
+	"github.com/golang/mock/gomock"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -37,14 +43,90 @@ func TestUnitHandlers(t *testing.T) {
 			So(w.Code, ShouldEqual, http.StatusInternalServerError)
 		})
 	})
+
+	Convey("test getAllDimensionOptions", t, func() {
+		mockCtrl := gomock.NewController(t)
+		mockFc := NewMockFilterClient(mockCtrl)
+
+		Convey("test every page of options is requested", func() {
+			first := filter.DimensionOptions{TotalCount: optionsPageSize + 1}
+			for i := 0; i < optionsPageSize; i++ {
+				first.Items = append(first.Items, filter.DimensionOption{Option: fmt.Sprintf("E%08d", i)})
+			}
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), "", "", "", "1234", "ltla", &filter.QueryParams{Offset: 0, Limit: optionsPageSize}).
+				Return(first, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), "", "", "", "1234", "ltla", &filter.QueryParams{Offset: optionsPageSize, Limit: optionsPageSize}).
+				Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "W06000001"}}, TotalCount: optionsPageSize + 1}, "", nil)
+
+			opts, err := getAllDimensionOptions(context.Background(), mockFc, "", "", "1234", "ltla")
+			So(err, ShouldBeNil)
+			So(opts.Items, ShouldHaveLength, optionsPageSize+1)
+			So(opts.Items[optionsPageSize].Option, ShouldEqual, "W06000001")
+			So(opts.Count, ShouldEqual, optionsPageSize+1)
+		})
+
+		Convey("test an error getting a page is returned", func() {
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), "", "", "", "1234", "ltla", gomock.Any()).
+				Return(filter.DimensionOptions{}, "", errors.New("internal error"))
+
+			_, err := getAllDimensionOptions(context.Background(), mockFc, "", "", "1234", "ltla")
+			So(err, ShouldNotBeNil)
+		})
+	})
+
+	Convey("test getAllDimensionOptionIDs", t, func() {
+		mockCtrl := gomock.NewController(t)
+		mockFc := NewMockFilterClient(mockCtrl)
+
+		Convey("test the ids of every page of options are returned", func() {
+			first := filter.DimensionOptions{TotalCount: optionsPageSize + 1}
+			for i := 0; i < optionsPageSize; i++ {
+				first.Items = append(first.Items, filter.DimensionOption{Option: fmt.Sprintf("E%08d", i)})
+			}
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), "", "", "", "1234", "ltla", &filter.QueryParams{Offset: 0, Limit: optionsPageSize}).
+				Return(first, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), "", "", "", "1234", "ltla", &filter.QueryParams{Offset: optionsPageSize, Limit: optionsPageSize}).
+				Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "W06000001"}}, TotalCount: optionsPageSize + 1}, "", nil)
+
+			ids, err := getAllDimensionOptionIDs(context.Background(), mockFc, "", "", "1234", "ltla")
+			So(err, ShouldBeNil)
+			So(ids, ShouldHaveLength, optionsPageSize+1)
+			So(ids[0], ShouldEqual, "E00000000")
+			So(ids[optionsPageSize], ShouldEqual, "W06000001")
+		})
+	})
 }
 
+// testGeography is the embedded geography rules, which the handlers are tested with
+var testGeography = func() *geography.Rules {
+	r, err := geography.Load("")
+	if err != nil {
+		panic(err)
+	}
+	return r
+}()
+
 func initialiseMockConfig() *config.Config {
 	return &config.Config{
 		PatternLibraryAssetsPath:    "http://localhost:9000/dist",
 		SiteDomain:                  "ons",
 		SupportedLanguages:          []string{"en", "cy"},
 		DefaultMaximumSearchResults: 50,
+		MaxSelectAllAreas:           500,
 		EnableMultivariate:          true,
+		EnableFilterHistory:         true,
+		FilterHistorySecret:         "secret",
+		PopulationCacheSize:         1000,
+		PopulationCacheTTL:          time.Hour,
 	}
 }
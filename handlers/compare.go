@@ -0,0 +1,214 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Compare Handler
+func (f *FilterFlex) Compare() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		compare(w, req, f, lang, accessToken, collectionID)
+	})
+}
+
+func compare(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang, accessToken, collectionID string) {
+	ctx := req.Context()
+	a := req.URL.Query().Get("a")
+	b := req.URL.Query().Get("b")
+	if a == "" || b == "" {
+		err := &clientErr{errors.New("two filter ids are required to compare")}
+		log.Error(ctx, "invalid comparison request", err, log.Data{"a": a, "b": b})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	var itemA, itemB model.FilterComparisonItem
+	var aErr, bErr, zErr error
+	var eb zebedee.EmergencyBanner
+	var serviceMsg string
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		eb, serviceMsg, zErr = getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	}()
+	go func() {
+		defer wg.Done()
+		itemA, aErr = f.getFilterComparisonItem(ctx, accessToken, collectionID, a)
+	}()
+	go func() {
+		defer wg.Done()
+		itemB, bErr = f.getFilterComparisonItem(ctx, accessToken, collectionID, b)
+	}()
+	wg.Wait()
+
+	// log zebedee error but don't set a server error
+	if zErr != nil {
+		log.Error(ctx, "unable to get homepage content", zErr, log.Data{"homepage_content": zErr})
+	}
+	if aErr != nil {
+		log.Error(ctx, "failed to get filter for comparison", aErr, log.Data{"filter_id": a})
+		setStatusCode(req, w, aErr)
+		return
+	}
+	if bErr != nil {
+		log.Error(ctx, "failed to get filter for comparison", bErr, log.Data{"filter_id": b})
+		setStatusCode(req, w, bErr)
+		return
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, "")
+	comparison := m.CreateFilterComparison(itemA, itemB)
+	f.Render.BuildPage(w, comparison, "compare")
+}
+
+// getFilterComparisonItem gets a filter with its dimensions, options and disclosure control outcome
+func (f *FilterFlex) getFilterComparisonItem(ctx context.Context, accessToken, collectionID, filterID string) (model.FilterComparisonItem, error) {
+	var item model.FilterComparisonItem
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		return item, err
+	}
+	item.Filter = *filterJob
+
+	d, err := f.DatasetClient.Get(ctx, accessToken, "", collectionID, filterJob.Dataset.DatasetID)
+	if err != nil {
+		return item, err
+	}
+	item.DatasetTitle = d.Title
+	item.IsMultivariate = f.EnableMultivariate && isMultivariateType(d)
+
+	filterDims, _, err := f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
+	if err != nil {
+		return item, err
+	}
+
+	var areaTypeID, parent string
+	var dimIds, areaOpts []string
+	for _, fd := range filterDims.Items {
+		// Needed to determine whether dimension is_area_type
+		dim, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterID, fd.Name)
+		if err != nil {
+			return item, fmt.Errorf("failed to get dimension %s: %w", fd.Name, err)
+		}
+		dimIds = append(dimIds, dim.ID)
+
+		opts, err := getAllDimensionOptions(ctx, f.FilterClient, accessToken, collectionID, filterID, dim.Name)
+		if err != nil {
+			return item, fmt.Errorf("failed to get options for dimension %s: %w", dim.Name, err)
+		}
+
+		dim.Options = []string{}
+		for _, opt := range opts.Items {
+			dim.Options = append(dim.Options, opt.Option)
+		}
+		if isAreaType(dim) {
+			areaTypeID = dim.ID
+			parent = dim.FilterByParent
+			areaOpts = copyStrings(dim.Options)
+		}
+		item.Dimensions = append(item.Dimensions, dim)
+	}
+
+	var areas []population.Area
+	var areasErr, baseErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		areaType := areaTypeID
+		if parent != "" {
+			areaType = parent
+		}
+		areas, areasErr = f.getAreasByID(ctx, accessToken, filterJob.PopulationType, areaType, areaOpts)
+	}()
+	go func() {
+		defer wg.Done()
+		item.BaseVariables, baseErr = f.getBaseVariables(ctx, accessToken, filterJob.PopulationType, item.Dimensions)
+	}()
+	wg.Wait()
+	if areasErr != nil {
+		return item, areasErr
+	}
+	if baseErr != nil {
+		return item, baseErr
+	}
+
+	for i, dim := range item.Dimensions {
+		if !isAreaType(dim) {
+			continue
+		}
+		for j, area := range areas {
+			if area.Label != "" {
+				item.Dimensions[i].Options[j] = area.Label
+			}
+		}
+	}
+
+	if item.IsMultivariate {
+		sdc, err := f.getBlockedAreaCount(ctx, accessToken, filterJob.PopulationType, areaTypeID, parent, dimIds, areaOpts)
+		if err != nil {
+			return item, fmt.Errorf("failed to get blocked area count: %w", err)
+		}
+		item.SDC = *sdc
+	}
+
+	return item, nil
+}
+
+// getBaseVariables concurrently gets the base variable of each dimension which is not an area type, keyed by dimension id
+func (f *FilterFlex) getBaseVariables(ctx context.Context, accessToken, populationType string, dims []filter.Dimension) (map[string]population.GetBaseVariableResponse, error) {
+	variables := make(map[string]population.GetBaseVariableResponse)
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for _, dim := range dims {
+		if isAreaType(dim) {
+			continue
+		}
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			variable, err := f.PopulationClient.GetBaseVariable(ctx, population.GetBaseVariableInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: accessToken,
+				},
+				PopulationType: populationType,
+				Variable:       id,
+			})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = fmt.Errorf("failed to get base variable of %s: %w", id, err)
+				}
+				return
+			}
+			variables[id] = variable
+		}(dim.ID)
+	}
+	wg.Wait()
+
+	return variables, firstErr
+}
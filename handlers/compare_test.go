@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCompareHandler(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+
+	mockGetFilter := func(_ interface{}, input filter.GetFilterInput) (*filter.GetFilterResponse, error) {
+		return &filter.GetFilterResponse{
+			FilterID:       input.FilterID,
+			PopulationType: "UR",
+			Dataset:        filter.Dataset{DatasetID: "example"},
+		}, nil
+	}
+	mockFilterDims := filter.Dimensions{
+		Items: []filter.Dimension{
+			{Name: "ltla"},
+			{Name: "sex"},
+		},
+	}
+	areaDim := filter.Dimension{
+		Name:       "ltla",
+		ID:         "ltla",
+		Label:      "Lower tier local authorities",
+		IsAreaType: helpers.ToBoolPtr(true),
+	}
+	sexDim := filter.Dimension{
+		Name:       "sex",
+		ID:         "sex_2a",
+		Label:      "Sex",
+		IsAreaType: helpers.ToBoolPtr(false),
+	}
+
+	expectFilterDimensions := func(mockFc *MockFilterClient) {
+		mockFc.EXPECT().GetFilter(ctx, gomock.Any()).DoAndReturn(mockGetFilter).Times(2)
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil).Times(2)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil).Times(2)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "sex").Return(sexDim, "", nil).Times(2)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", gomock.Any()).Return(filter.DimensionOptions{
+			Items: []filter.DimensionOption{{Option: "E06000001"}},
+		}, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "67890", "ltla", gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "sex", gomock.Any()).Return(filter.DimensionOptions{}, "", nil).Times(2)
+	}
+
+	Convey("Compare filters", t, func() {
+		Convey("When two filters are compared", func() {
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			expectFilterDimensions(mockFc)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(dataset.DatasetDetails{
+				Title: "Example",
+				Type:  "cantabular_multivariate_table",
+			}, nil).Times(2)
+			mockPc.EXPECT().GetArea(ctx, gomock.Any()).Return(population.GetAreaResponse{Area: population.Area{Label: "Hartlepool"}}, nil)
+			mockPc.EXPECT().GetBaseVariable(ctx, population.GetBaseVariableInput{
+				AuthTokens:     population.AuthTokens{},
+				PopulationType: "UR",
+				Variable:       "sex_2a",
+			}).Return(population.GetBaseVariableResponse{ID: "sex", Label: "Sex"}, nil).Times(2)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 1, Total: 1}, nil).Times(2)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "compare").Do(func(_ interface{}, p interface{}, _ string) {
+				page := p.(model.Comparison)
+				So(page.FilterA.FilterID, ShouldEqual, "12345")
+				So(page.FilterB.FilterID, ShouldEqual, "67890")
+				So(page.HasDifferences, ShouldBeTrue)
+				So(page.Rows[2].ValueA, ShouldEqual, "Hartlepool")
+			})
+
+			ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+			w := runCompare("/filters/compare?a=12345&b=67890", ff.Compare())
+
+			Convey("Then the status code is 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When multivariate datasets are disabled", func() {
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			expectFilterDimensions(mockFc)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(dataset.DatasetDetails{
+				Title: "Example",
+				Type:  "cantabular_multivariate_table",
+			}, nil).Times(2)
+			mockPc.EXPECT().GetArea(ctx, gomock.Any()).Return(population.GetAreaResponse{Area: population.Area{Label: "Hartlepool"}}, nil)
+			mockPc.EXPECT().GetBaseVariable(ctx, gomock.Any()).Return(population.GetBaseVariableResponse{ID: "sex", Label: "Sex"}, nil).Times(2)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "compare")
+
+			disabledCfg := *cfg
+			disabledCfg.EnableMultivariate = false
+			ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, &disabledCfg)
+			w := runCompare("/filters/compare?a=12345&b=67890", ff.Compare())
+
+			Convey("Then the disclosure control is not checked", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When a filter id is missing", func() {
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runCompare("/filters/compare?a=12345", ff.Compare())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When a filter cannot be found", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(nil, &testCliError{}).Times(2)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := runCompare("/filters/compare?a=12345&b=67890", ff.Compare())
+
+			Convey("Then the status code is 404", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+
+		Convey("When the dataset API responds with an error", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).DoAndReturn(mockGetFilter).Times(2)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.DatasetDetails{}, errors.New("sorry")).Times(2)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := runCompare("/filters/compare?a=12345&b=67890", ff.Compare())
+
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func runCompare(target string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", target, nil)
+	w := httptest.NewRecorder()
+	handler(w, req)
+	return w
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"strconv"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// getDatasetContext gets the title and release date of the dataset the filter customises. Errors are logged but not returned
+// as the context is not required to render a page
+func (f *FilterFlex) getDatasetContext(ctx context.Context, accessToken, collectionID string, ds filter.Dataset, isCustom bool) mapper.DatasetContext {
+	return f.getDatasetContextWithDetails(ctx, accessToken, collectionID, ds, isCustom, nil)
+}
+
+// getDatasetContextWithDetails gets the dataset context using the dataset details when the page has already got them,
+// otherwise they are got alongside the release date
+func (f *FilterFlex) getDatasetContextWithDetails(ctx context.Context, accessToken, collectionID string, ds filter.Dataset, isCustom bool, details *dataset.DatasetDetails) mapper.DatasetContext {
+	dc := mapper.DatasetContext{
+		Dataset:  ds,
+		IsCustom: isCustom,
+	}
+	logData := log.Data{
+		"dataset": ds.DatasetID,
+		"edition": ds.Edition,
+		"version": ds.Version,
+	}
+
+	var wg sync.WaitGroup
+	if details != nil {
+		dc.Title = details.Title
+	} else {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			details, err := f.DatasetClient.Get(ctx, accessToken, "", collectionID, ds.DatasetID)
+			if err != nil {
+				log.Error(ctx, "failed to get dataset", err, logData)
+				return
+			}
+			dc.Title = details.Title
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		releaseDate, err := getReleaseDate(ctx, f.DatasetClient, accessToken, collectionID, ds.DatasetID, ds.Edition, strconv.Itoa(ds.Version))
+		if err != nil {
+			log.Error(ctx, "failed to get dataset release date", err, logData)
+			return
+		}
+		dc.ReleaseDate = releaseDate
+	}()
+	wg.Wait()
+
+	return dc
+}
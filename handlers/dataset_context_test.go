@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// expectDatasetContext allows the dataset context shown on a filter page to be fetched, expectations which are already
+// set take precedence
+func expectDatasetContext(mockDc *MockDatasetClient) {
+	mockDc.
+		EXPECT().
+		Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(dataset.DatasetDetails{Title: "Dataset title"}, nil).
+		AnyTimes()
+	mockDc.
+		EXPECT().
+		GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(dataset.Version{Version: 1, ReleaseDate: "2022-11-29T09:30:00.000Z"}, nil).
+		AnyTimes()
+}
+
+// newDatasetContextClient returns a dataset client which only allows the dataset context to be fetched
+func newDatasetContextClient(mockCtrl *gomock.Controller) *MockDatasetClient {
+	mockDc := NewMockDatasetClient(mockCtrl)
+	expectDatasetContext(mockDc)
+	return mockDc
+}
+
+func TestGetDatasetContext(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := context.Background()
+	ds := filter.Dataset{DatasetID: "example", Edition: "2021", Version: 2}
+
+	Convey("Given a filter of a dataset", t, func() {
+		mockDc := NewMockDatasetClient(mockCtrl)
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), mockDc, NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+
+		Convey("When the dataset and its versions are found", func() {
+			mockDc.
+				EXPECT().
+				Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example").
+				Return(dataset.DatasetDetails{Title: "Example dataset"}, nil)
+			mockDc.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "2").
+				Return(dataset.Version{Version: 2, ReleaseDate: "2023-01-01"}, nil)
+			mockDc.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "1").
+				Return(dataset.Version{Version: 1, ReleaseDate: "2022-11-29"}, nil)
+
+			dc := ff.getDatasetContext(ctx, "", "", ds, false)
+
+			Convey("Then the context has the title and the release date of the edition", func() {
+				So(dc, ShouldResemble, mapper.DatasetContext{
+					Dataset:     ds,
+					Title:       "Example dataset",
+					ReleaseDate: "2022-11-29",
+				})
+			})
+		})
+
+		Convey("When the dataset client responds with errors", func() {
+			mockDc.
+				EXPECT().
+				Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(dataset.DatasetDetails{}, errors.New("internal error"))
+			mockDc.
+				EXPECT().
+				GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return(dataset.Version{}, errors.New("internal error"))
+
+			dc := ff.getDatasetContext(ctx, "", "", ds, true)
+
+			Convey("Then the errors are logged and an empty context is returned", func() {
+				So(dc, ShouldResemble, mapper.DatasetContext{Dataset: ds, IsCustom: true})
+			})
+		})
+	})
+}
@@ -3,10 +3,12 @@ package handlers
 import (
 	"errors"
 	"net/http"
+	"sort"
 	"strconv"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
 	"github.com/ONSdigital/dp-net/v3/handlers"
@@ -89,6 +91,7 @@ func dimensionSelector(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 		}
 
 		m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
+		m.SetDatasetContext(f.getDatasetContext(ctx, accessToken, collectionID, currentFilter.Dataset, helpers.IsBoolPtr(currentFilter.Custom)))
 		selector := m.CreateCategorisationsSelector(filterDimension.Label, dimensionName, cats)
 		f.Render.BuildPage(w, selector, "selector")
 		return
@@ -149,25 +152,83 @@ func dimensionSelector(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 		return
 	}
 
-	lowestGeography := overrideLowestGeography(details.LowestGeography, currentFilter.PopulationType, helpers.IsBoolPtr(currentFilter.Custom))
+	lowestGeography, highestGeography := f.geographyLimits(currentFilter, details.LowestGeography)
+
+	areaTypeDetails, err := f.getAreaTypeDetails(ctx, accessToken, collectionID, currentFilter.PopulationType, areaTypes.AreaTypes)
+	// log area type details error but don't set a server error, the area types are shown without them
+	if err != nil {
+		log.Error(ctx, "failed to get area type details", err, log.Data{
+			"filter_id":       filterID,
+			"population_type": currentFilter.PopulationType,
+		})
+	}
+
+	// the cached details are copied so the disclosure control outcomes of this filter can be added
+	selectorDetails := make(map[string]mapper.AreaTypeDetails, len(areaTypeDetails))
+	for id, detail := range areaTypeDetails {
+		selectorDetails[id] = detail
+	}
+	if f.EnableMultivariate && isMultivariateType(dataset) {
+		allowed := rangeAreaTypes(areaTypes.AreaTypes, lowestGeography, highestGeography)
+		sdc := f.getAreaTypesBlockedCount(ctx, accessToken, collectionID, filterID, currentFilter.PopulationType, filterDimension.ID, allowed)
+		for id, result := range sdc {
+			detail := selectorDetails[id]
+			detail.SDC = result
+			selectorDetails[id] = detail
+		}
+	}
 
 	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
-	selector := m.CreateAreaTypeSelector(areaTypes.AreaTypes, filterDimension, lowestGeography, releaseDate, dataset, hasOpts)
+	m.SetDatasetContext(mapper.DatasetContext{
+		Dataset:     currentFilter.Dataset,
+		Title:       dataset.Title,
+		ReleaseDate: releaseDate,
+		IsCustom:    helpers.IsBoolPtr(currentFilter.Custom),
+	})
+	selector := m.CreateAreaTypeSelector(areaTypes.AreaTypes, filterDimension, lowestGeography, highestGeography, releaseDate, dataset, hasOpts, selectorDetails)
 	f.Render.BuildPage(w, selector, "selector")
 }
 
-func overrideLowestGeography(defaultLowestGeography, populationType string, isCustom bool) string {
-	overrides := map[string]string{
-		"UR_CE": "msoa",
+// geographyLimits returns the lowest and highest area types allowed for the filter, the lowest geography of the dataset applies
+// when the geography rules do not limit it
+func (f *FilterFlex) geographyLimits(currentFilter filter.Model, datasetLowestGeography string) (lowest, highest string) {
+	lowest, highest = f.Geography.Limits(currentFilter.Dataset.DatasetID, currentFilter.PopulationType, helpers.IsBoolPtr(currentFilter.Custom))
+	if lowest == "" {
+		lowest = datasetLowestGeography
 	}
-	if isCustom {
-		lowestGeography, isOverridden := overrides[populationType]
-		if isOverridden {
-			return lowestGeography
+	return lowest, highest
+}
+
+// sortAreaTypes returns the area types in the order of the area type selector, from the highest to the lowest geography
+func sortAreaTypes(areaTypes []population.AreaType) []population.AreaType {
+	sorted := append([]population.AreaType{}, areaTypes...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Hierarchy_Order != sorted[j].Hierarchy_Order {
+			return sorted[i].Hierarchy_Order > sorted[j].Hierarchy_Order
 		}
-	}
+		return sorted[i].TotalCount < sorted[j].TotalCount
+	})
+	return sorted
+}
 
-	return defaultLowestGeography
+// rangeAreaTypes returns the area types between the highest and lowest geography, from the highest to the lowest
+func rangeAreaTypes(areaTypes []population.AreaType, lowest, highest string) []population.AreaType {
+	sorted := sortAreaTypes(areaTypes)
+	ids := make([]string, 0, len(sorted))
+	for _, areaType := range sorted {
+		ids = append(ids, areaType.ID)
+	}
+	allowed := map[string]bool{}
+	for _, id := range geography.Range(ids, lowest, highest) {
+		allowed[id] = true
+	}
+	var ranged []population.AreaType
+	for _, areaType := range sorted {
+		if allowed[areaType.ID] {
+			ranged = append(ranged, areaType)
+		}
+	}
+	return ranged
 }
 
 // isAreaType determines if the current dimension is an area type
@@ -72,7 +72,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 				w := runDimensionsSelector(
 					"number+of+siblings",
 					ff.DimensionSelector(),
@@ -129,7 +129,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetCategorisations(gomock.Any(), gomock.Any()).
 					Return(population.GetCategorisationsResponse{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 				w := runDimensionsSelector(
 					"number+of+siblings",
 					ff.DimensionSelector(),
@@ -167,7 +167,7 @@ func TestDimensionsHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 			w := runDimensionsSelector(
 				"city",
 				ff.DimensionSelector(),
@@ -232,6 +232,19 @@ func TestDimensionsHandler(t *testing.T) {
 							nil,
 						).
 						AnyTimes()
+					mockPc.EXPECT().
+						GetAreas(gomock.Any(), gomock.Any()).
+						Return(population.GetAreasResponse{}, nil).
+						AnyTimes()
+					mockPc.EXPECT().
+						GetAreaTypeParents(gomock.Any(), gomock.Any()).
+						Return(population.GetAreaTypeParentsResponse{}, nil).
+						AnyTimes()
+					mockFilter.
+						EXPECT().
+						GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(filter.Dimensions{}, "", nil).
+						AnyTimes()
 
 					mockRend := NewMockRenderClient(mockCtrl)
 					mockRend.EXPECT().
@@ -285,7 +298,7 @@ func TestDimensionsHandler(t *testing.T) {
 						GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(zebedee.HomepageContent{}, nil)
 
-					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 					w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 					Convey("And the status code should be 200", func() {
@@ -353,6 +366,19 @@ func TestDimensionsHandler(t *testing.T) {
 							nil,
 						).
 						AnyTimes()
+					mockPc.EXPECT().
+						GetAreas(gomock.Any(), gomock.Any()).
+						Return(population.GetAreasResponse{}, nil).
+						AnyTimes()
+					mockPc.EXPECT().
+						GetAreaTypeParents(gomock.Any(), gomock.Any()).
+						Return(population.GetAreaTypeParentsResponse{}, nil).
+						AnyTimes()
+					mockFilter.
+						EXPECT().
+						GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+						Return(filter.Dimensions{}, "", nil).
+						AnyTimes()
 
 					mockRend := NewMockRenderClient(mockCtrl)
 					mockRend.EXPECT().
@@ -388,7 +414,7 @@ func TestDimensionsHandler(t *testing.T) {
 						GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(zebedee.HomepageContent{}, nil)
 
-					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 					w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 					Convey("And the status code should be 200", func() {
@@ -450,7 +476,7 @@ func TestDimensionsHandler(t *testing.T) {
 						GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(zebedee.HomepageContent{}, nil)
 
-					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 					w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 					Convey("And the status code should be 200", func() {
@@ -510,7 +536,7 @@ func TestDimensionsHandler(t *testing.T) {
 						GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(zebedee.HomepageContent{}, nil)
 
-					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 					w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 					Convey("And the status code should be 200", func() {
@@ -576,7 +602,7 @@ func TestDimensionsHandler(t *testing.T) {
 						GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 						Return(zebedee.HomepageContent{}, nil)
 
-					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+					ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 					selector := ff.DimensionSelector()
 
 					w := httptest.NewRecorder()
@@ -636,7 +662,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 				w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 				Convey("Then the status code should be 500", func() {
@@ -693,7 +719,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, errors.New("Internal error"))
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 				w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 				Convey("Then the status code should be 200", func() {
@@ -742,7 +768,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 				w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 				Convey("Then the status code should be 500", func() {
@@ -780,7 +806,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 				w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 				Convey("Then the status code should be 500", func() {
@@ -824,7 +850,7 @@ func TestDimensionsHandler(t *testing.T) {
 					GetCategorisations(gomock.Any(), gomock.Any()).
 					Return(population.GetCategorisationsResponse{}, errors.New("Internal error"))
 
-				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFilter, mockDc, mockPc, mockZc, testGeography, cfg)
 				w := runDimensionsSelector(dimensionName, ff.DimensionSelector())
 
 				Convey("Then the status code should be 500", func() {
@@ -834,19 +860,6 @@ func TestDimensionsHandler(t *testing.T) {
 		})
 
 	})
-
-	Convey("Lowest geography override", t, func() {
-		Convey("Test the override function", func() {
-			hardcodedPopulation := "UR_CE"
-			hardcodedLG := "msoa"
-			defaultLG := "default"
-
-			So(overrideLowestGeography(defaultLG, hardcodedPopulation, true), ShouldEqual, hardcodedLG)
-			So(overrideLowestGeography(defaultLG, hardcodedPopulation, false), ShouldEqual, defaultLG)
-			So(overrideLowestGeography(defaultLG, "normal population", true), ShouldEqual, defaultLG)
-			So(overrideLowestGeography(defaultLG, "normal population", false), ShouldEqual, defaultLG)
-		})
-	})
 }
 
 func runDimensionsSelector(dimension string, selector func(http.ResponseWriter, *http.Request)) *httptest.ResponseRecorder {
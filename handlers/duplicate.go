@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// Duplicate Handler
+func (f *FilterFlex) Duplicate() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		duplicate(w, req, f, accessToken, collectionID)
+	})
+}
+
+func duplicate(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get filter", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	dims, err := f.getModelDimensions(ctx, accessToken, collectionID, filterID)
+	if err != nil {
+		log.Error(ctx, "failed to get dimensions", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	newFilterID, err := f.createFilter(ctx, accessToken, collectionID, filterJob, dims)
+	if err != nil {
+		log.Error(ctx, "failed to create filter", err, log.Data{
+			"filter_id":  filterID,
+			"dataset_id": filterJob.Dataset.DatasetID,
+			"edition":    filterJob.Dataset.Edition,
+			"version":    filterJob.Dataset.Version,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	f.recordFilterHistory(w, req, newFilterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", newFilterID), http.StatusMovedPermanently)
+}
+
+// createFilter creates a new filter of the dataset and population type of the given filter with the given dimensions,
+// the dimensions are sent in a single request so the new filter is created with all of them or not at all
+func (f *FilterFlex) createFilter(ctx context.Context, accessToken, collectionID string, filterJob *filter.GetFilterResponse, dims []filter.ModelDimension) (string, error) {
+	var newFilterID string
+	var err error
+	if helpers.IsBoolPtr(filterJob.Custom) {
+		newFilterID, _, err = f.FilterClient.CreateFlexibleBlueprintCustom(ctx, accessToken, "", "", filter.CreateFlexBlueprintCustomRequest{
+			Dataset:        filterJob.Dataset,
+			Dimensions:     dims,
+			PopulationType: filterJob.PopulationType,
+			CollectionID:   collectionID,
+		})
+	} else {
+		newFilterID, _, err = f.FilterClient.CreateFlexibleBlueprint(ctx, accessToken, "", "", collectionID,
+			filterJob.Dataset.DatasetID,
+			filterJob.Dataset.Edition,
+			strconv.Itoa(filterJob.Dataset.Version),
+			dims,
+			filterJob.PopulationType)
+	}
+	return newFilterID, err
+}
+
+// getModelDimensions gets the dimensions of a filter with their options as they are, so that they can be copied to a new filter
+func (f *FilterFlex) getModelDimensions(ctx context.Context, accessToken, collectionID, filterID string) ([]filter.ModelDimension, error) {
+	filterDims, _, err := f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
+	if err != nil {
+		return nil, err
+	}
+
+	var dims []filter.ModelDimension
+	for _, fd := range filterDims.Items {
+		// Needed to determine whether dimension is_area_type and filter_by_parent
+		dim, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterID, fd.Name)
+		if err != nil {
+			log.Error(ctx, "failed to get dimension", err, log.Data{"dimension_name": fd.Name})
+			return nil, err
+		}
+
+		opts, err := getAllDimensionOptions(ctx, f.FilterClient, accessToken, collectionID, filterID, fd.Name)
+		if err != nil {
+			log.Error(ctx, "failed to get options for dimension", err, log.Data{"dimension_name": fd.Name})
+			return nil, err
+		}
+
+		options := []string{}
+		for _, opt := range opts.Items {
+			options = append(options, opt.Option)
+		}
+
+		dims = append(dims, filter.ModelDimension{
+			Name:                 dim.Name,
+			ID:                   dim.ID,
+			Label:                dim.Label,
+			IsAreaType:           helpers.ToBoolPtr(isAreaType(dim)),
+			Options:              options,
+			FilterByParent:       dim.FilterByParent,
+			QualityStatementText: dim.QualityStatementText,
+			QualitySummaryURL:    dim.QualitySummaryURL,
+		})
+	}
+
+	return dims, nil
+}
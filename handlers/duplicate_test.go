@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDuplicateHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+
+	filterJob := &filter.GetFilterResponse{
+		FilterID:       "12345",
+		PopulationType: "UR",
+		Dataset: filter.Dataset{
+			DatasetID: "example",
+			Edition:   "2021",
+			Version:   1,
+		},
+	}
+	mockFilterDims := filter.Dimensions{
+		Items: []filter.Dimension{
+			{Name: "ltla"},
+			{Name: "sex"},
+		},
+	}
+	areaDim := filter.Dimension{
+		Name:           "ltla",
+		ID:             "ltla",
+		Label:          "Lower tier local authorities",
+		IsAreaType:     helpers.ToBoolPtr(true),
+		FilterByParent: "rgn",
+	}
+	sexDim := filter.Dimension{
+		Name:       "sex",
+		ID:         "sex_2a",
+		Label:      "Sex",
+		IsAreaType: helpers.ToBoolPtr(false),
+	}
+	expectedDims := []filter.ModelDimension{
+		{
+			Name:           "ltla",
+			ID:             "ltla",
+			Label:          "Lower tier local authorities",
+			IsAreaType:     helpers.ToBoolPtr(true),
+			Options:        []string{"E12000001"},
+			FilterByParent: "rgn",
+		},
+		{
+			Name:       "sex",
+			ID:         "sex_2a",
+			Label:      "Sex",
+			IsAreaType: helpers.ToBoolPtr(false),
+			Options:    []string{},
+		},
+	}
+
+	expectFilterDimensions := func(mockFc *MockFilterClient) {
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", gomock.Any()).Return(mockFilterDims, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla").Return(areaDim, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex").Return(sexDim, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", gomock.Any()).Return(filter.DimensionOptions{
+			Items: []filter.DimensionOption{{Option: "E12000001"}},
+		}, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex", gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+	}
+
+	Convey("Duplicate filter", t, func() {
+		Convey("When a filter is duplicated", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			expectFilterDimensions(mockFc)
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "1", expectedDims, "UR").Return("67890", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runDuplicate(ff.Duplicate())
+
+			Convey("Then the user is redirected to the new filter", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/67890/dimensions")
+			})
+			Convey("Then the new filter is recorded in the history", func() {
+				So(w.Result().Cookies(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a custom filter is duplicated", func() {
+			customJob := *filterJob
+			customJob.Custom = helpers.ToBoolPtr(true)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&customJob, nil)
+			expectFilterDimensions(mockFc)
+			mockFc.EXPECT().CreateFlexibleBlueprintCustom(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filter.CreateFlexBlueprintCustomRequest{
+				Dataset:        filterJob.Dataset,
+				Dimensions:     expectedDims,
+				PopulationType: "UR",
+			}).Return("67890", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runDuplicate(ff.Duplicate())
+
+			Convey("Then the user is redirected to the new custom filter", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/67890/dimensions")
+			})
+		})
+
+		Convey("When the filter has more area options than a page", func() {
+			firstPage := filter.DimensionOptions{TotalCount: optionsPageSize + 1}
+			for i := 0; i < optionsPageSize; i++ {
+				firstPage.Items = append(firstPage.Items, filter.DimensionOption{Option: fmt.Sprintf("E%08d", i)})
+			}
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", &filter.QueryParams{Offset: 0, Limit: optionsPageSize}).Return(firstPage, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", &filter.QueryParams{Offset: optionsPageSize, Limit: optionsPageSize}).Return(filter.DimensionOptions{
+				Items:      []filter.DimensionOption{{Option: "W00000001"}},
+				TotalCount: optionsPageSize + 1,
+			}, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex").Return(sexDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex", gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+			var dims []filter.ModelDimension
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "1", gomock.Any(), "UR").
+				Do(func(_ interface{}, _, _, _, _, _, _, _ string, d []filter.ModelDimension, _ string) {
+					dims = d
+				}).
+				Return("67890", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runDuplicate(ff.Duplicate())
+
+			Convey("Then every area option is copied", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(dims[0].Options, ShouldHaveLength, optionsPageSize+1)
+				So(dims[0].Options[optionsPageSize], ShouldEqual, "W00000001")
+			})
+		})
+
+		Convey("When the filter cannot be created", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			expectFilterDimensions(mockFc)
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", "", errors.New("sorry"))
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runDuplicate(ff.Duplicate())
+
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Header().Get("Location"), ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the filter cannot be found", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(nil, &testCliError{})
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runDuplicate(ff.Duplicate())
+
+			Convey("Then the status code is 404", func() {
+				So(w.Code, ShouldEqual, http.StatusNotFound)
+			})
+		})
+	})
+}
+
+func runDuplicate(handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/filters/12345/duplicate", nil)
+	w := httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/duplicate", handler)
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -140,6 +140,23 @@ func getChangeDimensions(w http.ResponseWriter, req *http.Request, f *FilterFlex
 		return
 	}
 
+	// variables are also found by their categories, for example searching for "students" finds economic activity status
+	var categoryMatches map[string]string
+	if isSearch && q != "" {
+		nameMatches := map[string]bool{}
+		for _, result := range pResults.Dimensions {
+			nameMatches[result.ID] = true
+		}
+		var unmatched []population.Dimension
+		for _, pDim := range pDims.Dimensions {
+			if !nameMatches[pDim.ID] {
+				unmatched = append(unmatched, pDim)
+			}
+		}
+		categoryMatches = f.getCategoryMatches(ctx, accessToken, collectionID, popType, q, unmatched)
+		pResults = mergeCategoryMatches(pResults, pDims.Dimensions, categoryMatches)
+	}
+
 	wg.Add(1)
 	dimErrs := make([]error, len(fDims.Items))
 	go func() {
@@ -247,6 +264,7 @@ func getChangeDimensions(w http.ResponseWriter, req *http.Request, f *FilterFlex
 
 	basePage := f.Render.NewBasePageModel()
 	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, fid)
-	dimensions := m.CreateGetChangeDimensions(q, form, dims, pDims, pResults, sdc)
+	m.SetDatasetContext(f.getDatasetContext(ctx, accessToken, collectionID, fj.Dataset, helpers.IsBoolPtr(fj.Custom)))
+	dimensions := m.CreateGetChangeDimensions(q, form, dims, pDims, pResults, categoryMatches, sdc)
 	f.Render.BuildPage(w, dimensions, "dimensions")
 }
@@ -102,7 +102,8 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -180,7 +181,8 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -219,7 +221,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/{filterID}/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -240,7 +242,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(filter.Dimensions{}, "", errors.New("Internal error"))
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -316,7 +318,8 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, errors.New("Internal error"))
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -367,7 +370,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -422,7 +425,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -461,7 +464,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -504,7 +507,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -552,7 +555,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -617,7 +620,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -681,7 +684,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
@@ -741,7 +744,7 @@ func TestGetChangeDimensionsHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/change", ff.GetChangeDimensions())
 				router.ServeHTTP(w, req)
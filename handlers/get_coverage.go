@@ -13,9 +13,12 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/pagination"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/welsh"
 	"github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
@@ -36,11 +39,14 @@ func getCoverage(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang,
 	q := req.URL.Query().Get("q")
 	pq := req.URL.Query().Get("pq")
 	p := req.URL.Query().Get("p")
+	level := req.URL.Query().Get("level")
+	parentArea := req.URL.Query().Get("parent")
 	page := req.URL.Query().Get("page")
 	currentPg, _ := strconv.Atoi(page)
 	if currentPg <= 0 {
 		currentPg = 1
 	}
+	size := pagination.GetPageSize(req.URL.Query().Get("size"), f.DefaultMaximumSearchResults)
 	isNameSearch := strings.Contains(req.URL.RawQuery, "q=")
 	isParentSearch := strings.Contains(req.URL.RawQuery, "p=")
 	var filterJob *filter.GetFilterResponse
@@ -141,13 +147,13 @@ func getCoverage(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang,
 	go func() {
 		defer wg.Done()
 		if isNameSearch && q != "" {
-			areas, nsErr = getAreas(ctx, f.DefaultMaximumSearchResults, f.PopulationClient, accessToken, filterJob.PopulationType, geogID, q, currentPg)
+			areas, nsErr = getAreas(ctx, size, f.PopulationClient, accessToken, filterJob.PopulationType, geogID, q, currentPg)
 		}
 	}()
 	go func() {
 		defer wg.Done()
 		if isParentSearch && pq != "" {
-			areas, psErr = getAreas(ctx, f.DefaultMaximumSearchResults, f.PopulationClient, accessToken, filterJob.PopulationType, p, pq, currentPg)
+			areas, psErr = getAreas(ctx, size, f.PopulationClient, accessToken, filterJob.PopulationType, p, pq, currentPg)
 		}
 	}()
 	wg.Wait()
@@ -204,6 +210,39 @@ func getCoverage(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang,
 		setStatusCode(req, w, rdErr)
 		return
 	}
+	var browseParent mapper.BrowseParent
+	if c == Browse {
+		var err error
+		level, err = getBrowseLevel(level, geogID, parents)
+		if err != nil {
+			log.Error(ctx, "invalid browse level", err, log.Data{"level": level})
+			setStatusCode(req, w, err)
+			return
+		}
+		if parentArea != "" {
+			browseParent, err = f.getBrowseParent(ctx, accessToken, filterJob.PopulationType, geogID, level, parentArea)
+			if err != nil {
+				log.Error(ctx, "failed to get larger area to browse", err, log.Data{
+					"population_type": filterJob.PopulationType,
+					"area_type":       level,
+					"area":            parentArea,
+				})
+				setStatusCode(req, w, err)
+				return
+			}
+		} else {
+			areas, err = getAreas(ctx, size, f.PopulationClient, accessToken, filterJob.PopulationType, level, "", currentPg)
+			if err != nil {
+				log.Error(ctx, "failed to get areas to browse", err, log.Data{
+					"population_type": filterJob.PopulationType,
+					"area":            level,
+				})
+				setStatusCode(req, w, err)
+				return
+			}
+		}
+	}
+
 	options := []model.SelectableElement{}
 	var areaType string
 	if hasFilterByParent {
@@ -239,31 +278,161 @@ func getCoverage(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang,
 
 	basePage := f.Render.NewBasePageModel()
 	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
-	coverage := m.CreateGetCoverage(geogLabel, q, pq, p, parent, c, dimension, geogID, releaseDate, datasetDetails, areas, options, parents, hasFilterByParent, currentPg)
+	m.SetDatasetContext(mapper.DatasetContext{
+		Dataset:     filterJob.Dataset,
+		Title:       datasetDetails.Title,
+		ReleaseDate: releaseDate,
+		IsCustom:    helpers.IsBoolPtr(filterJob.Custom),
+	})
+	m.SetBrowseParent(browseParent)
+	coverage := m.CreateGetCoverage(geogLabel, q, pq, p, parent, c, dimension, geogID, level, areas, options, parents, hasFilterByParent, currentPg)
 	f.Render.BuildPage(w, coverage, "coverage")
 }
 
-// getAreas is a helper function that returns the GetAreasResponse or an error
-func getAreas(ctx context.Context, defaultMaximumSearchResults int, pc PopulationClient, accessToken, popType, areaTypeID, query string, pageNo int) (population.GetAreasResponse, error) {
-	areas, err := pc.GetAreas(ctx, population.GetAreasInput{
+// getAreas is a helper function that returns the GetAreasResponse or an error.
+// Area codes are looked up directly, and searches without results are retried by the English name of a Welsh area name
+// and then with normalised forms of the query.
+func getAreas(ctx context.Context, pageSize int, pc PopulationClient, accessToken, popType, areaTypeID, query string, pageNo int) (population.GetAreasResponse, error) {
+	query = strings.TrimSpace(query)
+	if search.IsAreaCode(query) {
+		area, err := pc.GetArea(ctx, population.GetAreaInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: accessToken,
+			},
+			PopulationType: popType,
+			AreaType:       areaTypeID,
+			Area:           strings.ToUpper(query),
+		})
+		if err == nil {
+			areas := population.GetAreasResponse{
+				PaginationResponse: population.PaginationResponse{
+					PaginationParams: population.PaginationParams{
+						Limit: pageSize,
+					},
+					Count:      1,
+					TotalCount: 1,
+				},
+				Areas: []population.Area{area.Area},
+			}
+			return areas, validatePageNo(areas.TotalCount, areas.Limit, pageNo)
+		}
+		// codes which are not found are searched for as text
+		if cErr, ok := err.(ClientError); !ok || cErr.Code() != http.StatusNotFound {
+			return population.GetAreasResponse{}, err
+		}
+	}
+
+	areas, err := searchAreas(ctx, pageSize, pc, accessToken, popType, areaTypeID, query, pageNo)
+	if err != nil {
+		return areas, err
+	}
+
+	if query != "" {
+		// the population API only holds English names so Welsh names are searched for by their English name
+		if english, ok := welsh.EnglishName(query); ok && areas.TotalCount == 0 {
+			areas, err = searchAreas(ctx, pageSize, pc, accessToken, popType, areaTypeID, english, pageNo)
+			if err != nil {
+				return areas, err
+			}
+		}
+		if areas.TotalCount == 0 {
+			for _, variant := range search.Variants(query) {
+				areas, err = searchAreas(ctx, pageSize, pc, accessToken, popType, areaTypeID, variant, pageNo)
+				if err != nil {
+					return areas, err
+				}
+				if areas.TotalCount > 0 {
+					break
+				}
+			}
+		}
+		areas.Areas = search.Rank(query, areas.Areas)
+	}
+
+	err = validatePageNo(areas.TotalCount, areas.Limit, pageNo)
+
+	return areas, err
+}
+
+// searchAreas is a helper function that returns a page of areas matching the query
+func searchAreas(ctx context.Context, pageSize int, pc PopulationClient, accessToken, popType, areaTypeID, query string, pageNo int) (population.GetAreasResponse, error) {
+	return pc.GetAreas(ctx, population.GetAreasInput{
 		AuthTokens: population.AuthTokens{
 			UserAuthToken: accessToken,
 		},
 		PaginationParams: population.PaginationParams{
-			Limit:  defaultMaximumSearchResults,
-			Offset: pagination.GetOffset(defaultMaximumSearchResults, pageNo),
+			Limit:  pageSize,
+			Offset: pagination.GetOffset(pageSize, pageNo),
 		},
 		PopulationType: popType,
 		AreaTypeID:     areaTypeID,
-		Text:           url.QueryEscape(strings.TrimSpace(query)),
+		Text:           url.QueryEscape(query),
+	})
+}
+
+// getBrowseLevel returns the area type to browse, defaulting to the largest parent area type.
+// A client error is returned if the area type is not the selected area type or one of its parents.
+func getBrowseLevel(level, geogID string, parents population.GetAreaTypeParentsResponse) (string, error) {
+	if level == "" {
+		if len(parents.AreaTypes) == 0 {
+			return geogID, nil
+		}
+		largest := parents.AreaTypes[0]
+		for _, parent := range parents.AreaTypes[1:] {
+			if parent.Hierarchy_Order > largest.Hierarchy_Order ||
+				parent.Hierarchy_Order == largest.Hierarchy_Order && parent.TotalCount < largest.TotalCount {
+				largest = parent
+			}
+		}
+		return largest.ID, nil
+	}
+	if level == geogID {
+		return level, nil
+	}
+	for _, parent := range parents.AreaTypes {
+		if parent.ID == level {
+			return level, nil
+		}
+	}
+	return level, &clientErr{errors.New("invalid browse level")}
+}
+
+// getBrowseParent gets a larger area which is browsed into with the number of areas of the selected area type within it.
+// The population API cannot list the areas within a larger area, so they are added as a whole branch or searched for by name.
+func (f *FilterFlex) getBrowseParent(ctx context.Context, accessToken, popType, geogID, level, parentArea string) (mapper.BrowseParent, error) {
+	if level == geogID {
+		return mapper.BrowseParent{}, &clientErr{errors.New("areas of the selected area type cannot be browsed into")}
+	}
+
+	area, err := f.PopulationClient.GetArea(ctx, population.GetAreaInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PopulationType: popType,
+		AreaType:       level,
+		Area:           parentArea,
 	})
 	if err != nil {
-		return areas, err
+		return mapper.BrowseParent{}, err
 	}
 
-	err = validatePageNo(areas.TotalCount, areas.Limit, pageNo)
+	count, err := f.PopulationClient.GetParentAreaCount(ctx, population.GetParentAreaCountInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PopulationType:   popType,
+		AreaTypeID:       geogID,
+		ParentAreaTypeID: level,
+		Areas:            []string{parentArea},
+	})
+	if err != nil {
+		return mapper.BrowseParent{}, err
+	}
 
-	return areas, err
+	return mapper.BrowseParent{
+		Area:  area.Area,
+		Count: count,
+	}, nil
 }
 
 // validatePageNo checks that the given page number is within range and will return a client error if page number is out of range
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -12,6 +13,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 	gomock "github.com/golang/mock/gomock"
@@ -114,7 +116,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -176,7 +178,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, errors.New("Internal error"))
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -251,7 +253,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -327,7 +329,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -402,7 +404,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -479,7 +481,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -557,7 +559,7 @@ func TestGetCoverageHandler(t *testing.T) {
 					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(zebedee.HomepageContent{}, nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 				router.ServeHTTP(w, req)
@@ -566,6 +568,323 @@ func TestGetCoverageHandler(t *testing.T) {
 					So(w.Code, ShouldEqual, http.StatusOK)
 				})
 			})
+			Convey("When the user browses the area hierarchy", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions/geography/coverage?c=browse", nil)
+
+				mockRend := NewMockRenderClient(mockCtrl)
+				mockRend.
+					EXPECT().
+					NewBasePageModel().
+					Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+				mockRend.
+					EXPECT().
+					BuildPage(gomock.Any(), gomock.Any(), "coverage").
+					Do(func(_ interface{}, p interface{}, _ string) {
+						page := p.(model.Coverage)
+						So(page.BrowseLevel, ShouldEqual, "country")
+						So(page.BrowseLevels, ShouldHaveLength, 3)
+						So(page.BrowseOutput.Results, ShouldHaveLength, 1)
+					})
+
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockFc.
+					EXPECT().
+					GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockFilterDims, "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[0].Name).
+					Return(mockFilterDims.Items[0], "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[1].Name).
+					Return(mockFilterDims.Items[1], "", nil)
+				mockFc.EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(mockFilterVersion1, nil)
+				mockFc.EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
+
+				mockPc := NewMockPopulationClient(mockCtrl)
+				mockPc.EXPECT().
+					GetAreaTypeParents(gomock.Any(), gomock.Any()).
+					Return(population.GetAreaTypeParentsResponse{
+						AreaTypes: []population.AreaType{
+							{ID: "region", Label: "Region", Hierarchy_Order: 800},
+							{ID: "country", Label: "Country", Hierarchy_Order: 900},
+						},
+					}, nil)
+				mockPc.EXPECT().
+					GetAreas(gomock.Any(), population.GetAreasInput{
+						PaginationParams: population.PaginationParams{
+							Limit: cfg.DefaultMaximumSearchResults,
+						},
+						AreaTypeID: "country",
+					}).
+					Return(population.GetAreasResponse{
+						PaginationResponse: population.PaginationResponse{
+							PaginationParams: population.PaginationParams{Limit: cfg.DefaultMaximumSearchResults},
+							TotalCount:       1,
+						},
+						Areas: []population.Area{{ID: "E92000001", Label: "England"}},
+					}, nil)
+
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockDc.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockDataset, nil).AnyTimes()
+				mockDc.EXPECT().
+					GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockVersion1, nil).AnyTimes()
+
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockZc.
+					EXPECT().
+					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(zebedee.HomepageContent{}, nil)
+
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
+				router.ServeHTTP(w, req)
+
+				Convey("And the status code should be 200", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+				})
+			})
+
+			Convey("When the user browses into a larger area", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions/geography/coverage?c=browse&level=country&parent=E92000001", nil)
+
+				mockRend := NewMockRenderClient(mockCtrl)
+				mockRend.
+					EXPECT().
+					NewBasePageModel().
+					Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+				mockRend.
+					EXPECT().
+					BuildPage(gomock.Any(), gomock.Any(), "coverage").
+					Do(func(_ interface{}, p interface{}, _ string) {
+						page := p.(model.Coverage)
+						So(page.BrowseLevel, ShouldEqual, "country")
+						So(page.BrowseParent.Value, ShouldEqual, "E92000001")
+						So(page.BrowseBreadcrumbs, ShouldHaveLength, 2)
+						So(page.BrowseOutput.Results, ShouldBeEmpty)
+					})
+
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockFc.
+					EXPECT().
+					GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockFilterDims, "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[0].Name).
+					Return(mockFilterDims.Items[0], "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[1].Name).
+					Return(mockFilterDims.Items[1], "", nil)
+				mockFc.EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(mockFilterVersion1, nil)
+				mockFc.EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
+
+				mockPc := NewMockPopulationClient(mockCtrl)
+				mockPc.EXPECT().
+					GetAreaTypeParents(gomock.Any(), gomock.Any()).
+					Return(population.GetAreaTypeParentsResponse{
+						AreaTypes: []population.AreaType{
+							{ID: "region", Label: "Region", Hierarchy_Order: 800},
+							{ID: "country", Label: "Country", Hierarchy_Order: 900},
+						},
+					}, nil)
+				mockPc.EXPECT().
+					GetArea(gomock.Any(), population.GetAreaInput{
+						AreaType: "country",
+						Area:     "E92000001",
+					}).
+					Return(population.GetAreaResponse{
+						Area: population.Area{ID: "E92000001", Label: "England", AreaType: "country"},
+					}, nil)
+				mockPc.EXPECT().
+					GetParentAreaCount(gomock.Any(), population.GetParentAreaCountInput{
+						AreaTypeID:       "city",
+						ParentAreaTypeID: "country",
+						Areas:            []string{"E92000001"},
+					}).
+					Return(52, nil)
+
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockDc.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockDataset, nil).AnyTimes()
+				mockDc.EXPECT().
+					GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockVersion1, nil).AnyTimes()
+
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockZc.
+					EXPECT().
+					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(zebedee.HomepageContent{}, nil)
+
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
+				router.ServeHTTP(w, req)
+
+				Convey("And the status code should be 200", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+				})
+			})
+
+			Convey("When the user browses into an area of the selected area type", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions/geography/coverage?c=browse&level=city&parent=E34000001", nil)
+
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockFc.
+					EXPECT().
+					GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockFilterDims, "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[0].Name).
+					Return(mockFilterDims.Items[0], "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[1].Name).
+					Return(mockFilterDims.Items[1], "", nil)
+				mockFc.EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(mockFilterVersion1, nil)
+				mockFc.EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
+
+				mockPc := NewMockPopulationClient(mockCtrl)
+				mockPc.EXPECT().
+					GetAreaTypeParents(gomock.Any(), gomock.Any()).
+					Return(population.GetAreaTypeParentsResponse{
+						AreaTypes: []population.AreaType{
+							{ID: "country", Label: "Country", Hierarchy_Order: 900},
+						},
+					}, nil)
+
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockDc.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockDataset, nil).AnyTimes()
+				mockDc.EXPECT().
+					GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockVersion1, nil).AnyTimes()
+
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockZc.
+					EXPECT().
+					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(zebedee.HomepageContent{}, nil)
+
+				ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
+				router.ServeHTTP(w, req)
+
+				Convey("Then the status code should be 400", func() {
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+				})
+			})
+
+			Convey("When the user chooses a page size", func() {
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions/geography/coverage?c=name-search&q=name&size=10&page=2", nil)
+
+				mockRend := NewMockRenderClient(mockCtrl)
+				mockRend.
+					EXPECT().
+					NewBasePageModel().
+					Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+				mockRend.
+					EXPECT().
+					BuildPage(gomock.Any(), gomock.Any(), "coverage").
+					Do(func(_ interface{}, p interface{}, _ string) {
+						page := p.(model.Coverage)
+						So(page.NameSearchOutput.Pagination.Limit, ShouldEqual, 10)
+						So(page.NameSearchOutput.Pagination.Previous.URL, ShouldContainSubstring, "size=10")
+						So(page.NameSearchOutput.PageSizes[0].IsCurrent, ShouldBeTrue)
+					})
+
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockFc.
+					EXPECT().
+					GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockFilterDims, "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[0].Name).
+					Return(mockFilterDims.Items[0], "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), mockFilterDims.Items[1].Name).
+					Return(mockFilterDims.Items[1], "", nil)
+				mockFc.EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(mockFilterVersion1, nil)
+				mockFc.EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
+
+				mockPc := NewMockPopulationClient(mockCtrl)
+				mockPc.EXPECT().
+					GetAreaTypeParents(gomock.Any(), gomock.Any()).
+					Return(population.GetAreaTypeParentsResponse{}, nil)
+				mockPc.EXPECT().
+					GetAreas(gomock.Any(), population.GetAreasInput{
+						PaginationParams: population.PaginationParams{
+							Limit:  10,
+							Offset: 10,
+						},
+						AreaTypeID: "city",
+						Text:       "name",
+					}).
+					Return(population.GetAreasResponse{
+						PaginationResponse: population.PaginationResponse{
+							PaginationParams: population.PaginationParams{Limit: 10, Offset: 10},
+							Count:            1,
+							TotalCount:       11,
+						},
+						Areas: []population.Area{{ID: "E06000001", Label: "Hartlepool"}},
+					}, nil)
+
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockDc.EXPECT().
+					Get(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockDataset, nil).AnyTimes()
+				mockDc.EXPECT().
+					GetVersion(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockVersion1, nil).AnyTimes()
+
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockZc.
+					EXPECT().
+					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(zebedee.HomepageContent{}, nil)
+
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
+				router.ServeHTTP(w, req)
+
+				Convey("Then the areas are requested with the chosen page size and the status code should be 200", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+				})
+			})
 		})
 
 		Convey("When the GetFilter API call responds with an error", func() {
@@ -589,7 +908,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -620,7 +939,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -654,7 +973,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -707,7 +1026,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -760,7 +1079,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 
@@ -837,7 +1156,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -894,7 +1213,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -951,7 +1270,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -1017,7 +1336,7 @@ func TestGetCoverageHandler(t *testing.T) {
 				GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				Return(zebedee.HomepageContent{}, nil)
 
-			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 			router := mux.NewRouter()
 			router.HandleFunc("/filters/12345/dimensions/geography/coverage", ff.GetCoverage())
 			router.ServeHTTP(w, req)
@@ -1028,3 +1347,169 @@ func TestGetCoverageHandler(t *testing.T) {
 		})
 	})
 }
+
+func TestGetBrowseLevel(t *testing.T) {
+	parents := population.GetAreaTypeParentsResponse{
+		AreaTypes: []population.AreaType{
+			{ID: "rgn", Hierarchy_Order: 800},
+			{ID: "ctry", Hierarchy_Order: 900},
+		},
+	}
+	Convey("When no level is given", t, func() {
+		Convey("Then the largest parent area type is returned", func() {
+			level, err := getBrowseLevel("", "ltla", parents)
+			So(err, ShouldBeNil)
+			So(level, ShouldEqual, "ctry")
+		})
+		Convey("Then the selected area type is returned when there are no parents", func() {
+			level, err := getBrowseLevel("", "ltla", population.GetAreaTypeParentsResponse{})
+			So(err, ShouldBeNil)
+			So(level, ShouldEqual, "ltla")
+		})
+	})
+	Convey("When a valid level is given", t, func() {
+		Convey("Then the level is returned", func() {
+			level, err := getBrowseLevel("rgn", "ltla", parents)
+			So(err, ShouldBeNil)
+			So(level, ShouldEqual, "rgn")
+
+			level, err = getBrowseLevel("ltla", "ltla", parents)
+			So(err, ShouldBeNil)
+			So(level, ShouldEqual, "ltla")
+		})
+	})
+	Convey("When an unknown level is given", t, func() {
+		Convey("Then a client error is returned", func() {
+			_, err := getBrowseLevel("msoa", "ltla", parents)
+			So(err, ShouldNotBeNil)
+			So(err.(ClientError).Code(), ShouldEqual, http.StatusBadRequest)
+		})
+	})
+}
+
+func TestGetAreas(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	found := population.GetAreasResponse{
+		PaginationResponse: population.PaginationResponse{
+			PaginationParams: population.PaginationParams{Limit: 10},
+			TotalCount:       2,
+		},
+		Areas: []population.Area{
+			{ID: "E06000001", Label: "Manchester Airport"},
+			{ID: "E08000003", Label: "Manchester"},
+		},
+	}
+	notFound := population.GetAreasResponse{
+		PaginationResponse: population.PaginationResponse{
+			PaginationParams: population.PaginationParams{Limit: 10},
+		},
+	}
+
+	Convey("When the search has results", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("Manchester")).Return(found, nil)
+
+		areas, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", " Manchester ", 1)
+
+		Convey("Then the areas are ranked by how closely they match", func() {
+			So(err, ShouldBeNil)
+			So(areas.Areas[0].Label, ShouldEqual, "Manchester")
+		})
+	})
+
+	Convey("When the search has no results", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		gomock.InOrder(
+			mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("Manchestr")).Return(notFound, nil),
+			mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("manchestr")).Return(notFound, nil),
+			mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("manchest")).Return(found, nil),
+		)
+
+		areas, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "Manchestr", 1)
+
+		Convey("Then the search is retried until a variant of the query has results", func() {
+			So(err, ShouldBeNil)
+			So(areas.TotalCount, ShouldEqual, 2)
+		})
+	})
+
+	Convey("When the search is for a Welsh area name", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		gomock.InOrder(
+			mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("Caerdydd")).Return(notFound, nil),
+			mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("Cardiff")).Return(found, nil),
+		)
+
+		areas, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "Caerdydd", 1)
+
+		Convey("Then the area is searched for by its English name", func() {
+			So(err, ShouldBeNil)
+			So(areas.TotalCount, ShouldEqual, 2)
+		})
+	})
+
+	Convey("When no variant of the query has results", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetAreas(ctx, gomock.Any()).Return(notFound, nil).Times(2)
+
+		areas, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "Bath", 1)
+
+		Convey("Then no areas are returned", func() {
+			So(err, ShouldBeNil)
+			So(areas.Areas, ShouldBeEmpty)
+		})
+	})
+
+	Convey("When the search is for an area code", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetArea(ctx, population.GetAreaInput{
+			PopulationType: "UR",
+			AreaType:       "ltla",
+			Area:           "E08000003",
+		}).Return(population.GetAreaResponse{Area: population.Area{ID: "E08000003", Label: "Manchester"}}, nil)
+
+		areas, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "e08000003", 1)
+
+		Convey("Then the area is returned", func() {
+			So(err, ShouldBeNil)
+			So(areas.TotalCount, ShouldEqual, 1)
+			So(areas.Areas[0].Label, ShouldEqual, "Manchester")
+		})
+	})
+
+	Convey("When the area code is not found", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetArea(ctx, gomock.Any()).Return(population.GetAreaResponse{}, &testCliError{})
+		mockPc.EXPECT().GetAreas(ctx, areasTextMatcher("E08000003")).Return(found, nil)
+
+		_, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "E08000003", 1)
+
+		Convey("Then the code is searched for as text", func() {
+			So(err, ShouldBeNil)
+		})
+	})
+
+	Convey("When the area lookup responds with an error", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetArea(ctx, gomock.Any()).Return(population.GetAreaResponse{}, errors.New("sorry"))
+
+		_, err := getAreas(ctx, 10, mockPc, "", "UR", "ltla", "E08000003", 1)
+
+		Convey("Then the error is returned", func() {
+			So(err, ShouldNotBeNil)
+		})
+	})
+}
+
+// areasTextMatcher matches GetAreasInput with the given search text
+type areasTextMatcher string
+
+func (m areasTextMatcher) Matches(x interface{}) bool {
+	input, ok := x.(population.GetAreasInput)
+	return ok && input.Text == string(m)
+}
+
+func (m areasTextMatcher) String() string {
+	return "has search text " + string(m)
+}
@@ -1,6 +1,14 @@
 package handlers
 
-import "github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+import (
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/cache"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/history"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+)
 
 // FilterFlex represents the handlers for filtering and flexing
 type FilterFlex struct {
@@ -9,19 +17,34 @@ type FilterFlex struct {
 	DatasetClient               DatasetClient
 	PopulationClient            PopulationClient
 	ZebedeeClient               ZebedeeClient
+	Geography                   *geography.Rules
+	History                     history.Store
 	EnableMultivariate          bool
 	DefaultMaximumSearchResults int
+	MaxSelectAllAreas           int
+	AreaTypeDetails             *cache.Cache[map[string]mapper.AreaTypeDetails]
+	BlockedAreaCounts           *cache.Cache[cantabular.GetBlockedAreaCountResult]
+	Categorisations             *cache.Cache[population.GetCategorisationsResponse]
 }
 
 // NewFilterFlex creates a new instance of FilterFlex
-func NewFilterFlex(rc RenderClient, fc FilterClient, dc DatasetClient, pc PopulationClient, zc ZebedeeClient, cfg *config.Config) *FilterFlex {
-	return &FilterFlex{
+func NewFilterFlex(rc RenderClient, fc FilterClient, dc DatasetClient, pc PopulationClient, zc ZebedeeClient, geo *geography.Rules, cfg *config.Config) *FilterFlex {
+	ff := &FilterFlex{
 		Render:                      rc,
 		FilterClient:                fc,
 		DatasetClient:               dc,
 		PopulationClient:            pc,
 		ZebedeeClient:               zc,
+		Geography:                   geo,
 		EnableMultivariate:          cfg.EnableMultivariate,
 		DefaultMaximumSearchResults: cfg.DefaultMaximumSearchResults,
+		MaxSelectAllAreas:           cfg.MaxSelectAllAreas,
+		AreaTypeDetails:             cache.New[map[string]mapper.AreaTypeDetails](cfg.PopulationCacheTTL, cfg.PopulationCacheSize),
+		BlockedAreaCounts:           cache.New[cantabular.GetBlockedAreaCountResult](cfg.PopulationCacheTTL, cfg.PopulationCacheSize),
+		Categorisations:             cache.New[population.GetCategorisationsResponse](cfg.PopulationCacheTTL, cfg.PopulationCacheSize),
 	}
+	if cfg.EnableFilterHistory {
+		ff.History = history.NewCookieStore(cfg.FilterHistorySecret, cfg.MaxFilterHistory)
+	}
+	return ff
 }
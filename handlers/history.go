@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/history"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// RecentFilters Handler
+func (f *FilterFlex) RecentFilters() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		recentFilters(w, req, f, lang, accessToken, collectionID)
+	})
+}
+
+func recentFilters(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang, accessToken, collectionID string) {
+	ctx := req.Context()
+
+	entries, err := f.History.Get(req)
+	if err != nil {
+		// an unreadable history is treated as empty and replaced on the next change
+		log.Error(ctx, "failed to get filter history", err)
+	}
+
+	eb, serviceMsg, err := getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	if err != nil {
+		log.Error(ctx, "unable to get homepage content", err, log.Data{"homepage_content": err})
+	}
+
+	items := make([]model.FilterHistoryItem, len(entries))
+	var wg sync.WaitGroup
+	for i, entry := range entries {
+		wg.Add(1)
+		go func(i int, entry history.Entry) {
+			defer wg.Done()
+			items[i] = getFilterHistoryItem(ctx, f, accessToken, collectionID, entry)
+		}(i, entry)
+	}
+	wg.Wait()
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, "")
+	recent := m.CreateRecentFilters(items)
+	f.Render.BuildPage(w, recent, "recent-filters")
+}
+
+// getFilterHistoryItem summarises a filter in the user's history.
+// Filters which no longer exist are marked as expired, and other errors are logged rather than failing the page.
+func getFilterHistoryItem(ctx context.Context, f *FilterFlex, accessToken, collectionID string, entry history.Entry) model.FilterHistoryItem {
+	item := model.FilterHistoryItem{
+		FilterID:    entry.FilterID,
+		LastChanged: entry.LastChanged,
+	}
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: entry.FilterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get filter", err, log.Data{"filter_id": entry.FilterID})
+		if cErr, ok := err.(ClientError); ok && cErr.Code() == http.StatusNotFound {
+			item.IsExpired = true
+		}
+		return item
+	}
+	item.Filter = *filterJob
+
+	var dErr, dsErr error
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		var dims filter.Dimensions
+		dims, _, dErr = f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, entry.FilterID, &filter.QueryParams{Limit: 500})
+		item.Dimensions = dims.Items
+	}()
+	go func() {
+		defer wg.Done()
+		d, err := f.DatasetClient.Get(ctx, accessToken, "", collectionID, filterJob.Dataset.DatasetID)
+		item.DatasetTitle, dsErr = d.Title, err
+	}()
+	wg.Wait()
+
+	if dErr != nil {
+		log.Error(ctx, "failed to get dimensions", dErr, log.Data{"filter_id": entry.FilterID})
+	}
+	if dsErr != nil {
+		log.Error(ctx, "failed to get dataset", dsErr, log.Data{"dataset_id": filterJob.Dataset.DatasetID})
+	}
+
+	return item
+}
+
+// recordFilterHistory adds the filter to the user's filter history, logging any error as the history is not required to continue
+func (f *FilterFlex) recordFilterHistory(w http.ResponseWriter, req *http.Request, filterID string, modified bool) {
+	if f.History == nil || filterID == "" {
+		return
+	}
+	if err := f.History.Add(w, req, filterID, modified); err != nil {
+		log.Error(req.Context(), "failed to record filter history", err, log.Data{"filter_id": filterID})
+	}
+}
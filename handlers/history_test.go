@@ -0,0 +1,129 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRecentFiltersHandler(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+
+	Convey("Recent filters", t, func() {
+		Convey("When the user has a filter history", func() {
+			ff := NewFilterFlex(nil, nil, nil, nil, nil, testGeography, cfg)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/", nil)
+			So(ff.History.Add(w, req, "expired", true), ShouldBeNil)
+			req = httptest.NewRequest("GET", "/", nil)
+			req.AddCookie(w.Result().Cookies()[0])
+			w = httptest.NewRecorder()
+			So(ff.History.Add(w, req, "12345", true), ShouldBeNil)
+			cookie := w.Result().Cookies()[0]
+
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, filter.GetFilterInput{FilterID: "12345"}).Return(&filter.GetFilterResponse{
+				FilterID: "12345",
+				State:    "submitted",
+				Dataset:  filter.Dataset{DatasetID: "example"},
+			}, nil)
+			mockFc.EXPECT().GetFilter(ctx, filter.GetFilterInput{FilterID: "expired"}).Return(nil, &testCliError{})
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", gomock.Any()).Return(filter.Dimensions{
+				Items: []filter.Dimension{{Label: "Sex (2 categories)"}},
+			}, "", nil)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(dataset.DatasetDetails{Title: "Example dataset"}, nil)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "recent-filters").Do(func(_ interface{}, p interface{}, _ string) {
+				page := p.(model.RecentFilters)
+				So(page.Filters, ShouldHaveLength, 2)
+				So(page.Filters[0].FilterID, ShouldEqual, "12345")
+				So(page.Filters[0].DatasetTitle, ShouldEqual, "Example dataset")
+				So(page.Filters[0].IsSubmitted, ShouldBeTrue)
+				So(page.Filters[0].Dimensions, ShouldResemble, []string{"Sex"})
+				So(page.Filters[1].FilterID, ShouldEqual, "expired")
+				So(page.Filters[1].IsExpired, ShouldBeTrue)
+			})
+
+			ff = NewFilterFlex(mockRend, mockFc, mockDc, NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w = httptest.NewRecorder()
+			req = httptest.NewRequest("GET", "/filters/recent", nil)
+			req.AddCookie(cookie)
+			router := mux.NewRouter()
+			router.HandleFunc("/filters/recent", ff.RecentFilters())
+			router.ServeHTTP(w, req)
+
+			Convey("Then the status code is 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the user has no filter history", func() {
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "recent-filters").Do(func(_ interface{}, p interface{}, _ string) {
+				So(p.(model.RecentFilters).Filters, ShouldBeEmpty)
+			})
+
+			ff := NewFilterFlex(mockRend, NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := httptest.NewRecorder()
+			router := mux.NewRouter()
+			router.HandleFunc("/filters/recent", ff.RecentFilters())
+			router.ServeHTTP(w, httptest.NewRequest("GET", "/filters/recent", nil))
+
+			Convey("Then the status code is 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+	})
+
+	Convey("Record history", t, func() {
+		Convey("When a change to a filter is saved", func() {
+			ff := NewFilterFlex(nil, nil, nil, nil, nil, testGeography, cfg)
+			w := httptest.NewRecorder()
+			ff.recordFilterHistory(w, httptest.NewRequest("POST", "/filters/12345/submit", nil), "12345", true)
+
+			Convey("Then the filter is recorded as modified", func() {
+				req := httptest.NewRequest("GET", "/filters/recent", nil)
+				req.AddCookie(w.Result().Cookies()[0])
+				entries, err := ff.History.Get(req)
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 1)
+				So(entries[0].FilterID, ShouldEqual, "12345")
+				So(entries[0].LastChanged.IsZero(), ShouldBeFalse)
+			})
+		})
+
+		Convey("When the filter history is disabled", func() {
+			disabled := *cfg
+			disabled.EnableFilterHistory = false
+			ff := NewFilterFlex(nil, nil, nil, nil, nil, testGeography, &disabled)
+			w := httptest.NewRecorder()
+			ff.recordFilterHistory(w, httptest.NewRequest("POST", "/filters/12345/submit", nil), "12345", true)
+
+			Convey("Then the filter is not recorded", func() {
+				So(ff.History, ShouldBeNil)
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+	})
+}
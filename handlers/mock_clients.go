@@ -171,6 +171,38 @@ func (mr *MockFilterClientMockRecorder) AddFlexDimension(ctx, userAuthToken, ser
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFlexDimension", reflect.TypeOf((*MockFilterClient)(nil).AddFlexDimension), ctx, userAuthToken, serviceAuthToken, collectionID, id, name, options, isAreaType, ifMatch)
 }
 
+// CreateFlexibleBlueprint mocks base method.
+func (m *MockFilterClient) CreateFlexibleBlueprint(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version string, dimensions []filter.ModelDimension, populationType string) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFlexibleBlueprint", ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, dimensions, populationType)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateFlexibleBlueprint indicates an expected call of CreateFlexibleBlueprint.
+func (mr *MockFilterClientMockRecorder) CreateFlexibleBlueprint(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, dimensions, populationType interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlexibleBlueprint", reflect.TypeOf((*MockFilterClient)(nil).CreateFlexibleBlueprint), ctx, userAuthToken, serviceAuthToken, downloadServiceToken, collectionID, datasetID, edition, version, dimensions, populationType)
+}
+
+// CreateFlexibleBlueprintCustom mocks base method.
+func (m *MockFilterClient) CreateFlexibleBlueprintCustom(ctx context.Context, userAuthToken, serviceAuthToken, downloadServiceToken string, req filter.CreateFlexBlueprintCustomRequest) (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFlexibleBlueprintCustom", ctx, userAuthToken, serviceAuthToken, downloadServiceToken, req)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateFlexibleBlueprintCustom indicates an expected call of CreateFlexibleBlueprintCustom.
+func (mr *MockFilterClientMockRecorder) CreateFlexibleBlueprintCustom(ctx, userAuthToken, serviceAuthToken, downloadServiceToken, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFlexibleBlueprintCustom", reflect.TypeOf((*MockFilterClient)(nil).CreateFlexibleBlueprintCustom), ctx, userAuthToken, serviceAuthToken, downloadServiceToken, req)
+}
+
 // DeleteDimensionOptions mocks base method.
 func (m *MockFilterClient) DeleteDimensionOptions(ctx context.Context, userAuthToken, serviceAuthToken, collectionID, filterID, name string) (string, error) {
 	m.ctrl.T.Helper()
@@ -493,6 +525,21 @@ func (mr *MockPopulationClientMockRecorder) GetAreas(ctx, input interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAreas", reflect.TypeOf((*MockPopulationClient)(nil).GetAreas), ctx, input)
 }
 
+// GetBaseVariable mocks base method.
+func (m *MockPopulationClient) GetBaseVariable(ctx context.Context, input population.GetBaseVariableInput) (population.GetBaseVariableResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBaseVariable", ctx, input)
+	ret0, _ := ret[0].(population.GetBaseVariableResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBaseVariable indicates an expected call of GetBaseVariable.
+func (mr *MockPopulationClientMockRecorder) GetBaseVariable(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBaseVariable", reflect.TypeOf((*MockPopulationClient)(nil).GetBaseVariable), ctx, input)
+}
+
 // GetBlockedAreaCount mocks base method.
 func (m *MockPopulationClient) GetBlockedAreaCount(ctx context.Context, input population.GetBlockedAreaCountInput) (*cantabular.GetBlockedAreaCountResult, error) {
 	m.ctrl.T.Helper()
@@ -568,6 +615,21 @@ func (mr *MockPopulationClientMockRecorder) GetDimensionsDescription(ctx, input
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDimensionsDescription", reflect.TypeOf((*MockPopulationClient)(nil).GetDimensionsDescription), ctx, input)
 }
 
+// GetParentAreaCount mocks base method.
+func (m *MockPopulationClient) GetParentAreaCount(ctx context.Context, input population.GetParentAreaCountInput) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetParentAreaCount", ctx, input)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetParentAreaCount indicates an expected call of GetParentAreaCount.
+func (mr *MockPopulationClientMockRecorder) GetParentAreaCount(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParentAreaCount", reflect.TypeOf((*MockPopulationClient)(nil).GetParentAreaCount), ctx, input)
+}
+
 // GetPopulationType mocks base method.
 func (m *MockPopulationClient) GetPopulationType(ctx context.Context, input population.GetPopulationTypeInput) (population.GetPopulationTypeResponse, error) {
 	m.ctrl.T.Helper()
@@ -583,6 +645,21 @@ func (mr *MockPopulationClientMockRecorder) GetPopulationType(ctx, input interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopulationType", reflect.TypeOf((*MockPopulationClient)(nil).GetPopulationType), ctx, input)
 }
 
+// GetPopulationTypes mocks base method.
+func (m *MockPopulationClient) GetPopulationTypes(ctx context.Context, input population.GetPopulationTypesInput) (population.GetPopulationTypesResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPopulationTypes", ctx, input)
+	ret0, _ := ret[0].(population.GetPopulationTypesResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPopulationTypes indicates an expected call of GetPopulationTypes.
+func (mr *MockPopulationClientMockRecorder) GetPopulationTypes(ctx, input interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPopulationTypes", reflect.TypeOf((*MockPopulationClient)(nil).GetPopulationTypes), ctx, input)
+}
+
 // MockZebedeeClient is a mock of ZebedeeClient interface.
 type MockZebedeeClient struct {
 	ctrl     *gomock.Controller
@@ -0,0 +1,193 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// Rebuild Handler
+func (f *FilterFlex) Rebuild() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		rebuild(w, req, f, accessToken, collectionID)
+	})
+}
+
+// rebuild creates a copy of the filter against the latest version of its dataset
+func rebuild(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	logData := log.Data{"filter_id": filterID}
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+	if helpers.IsBoolPtr(filterJob.Custom) {
+		err = &clientErr{errors.New("custom filters are not based on a dataset version")}
+		log.Error(ctx, "failed to rebuild filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	dims, err := f.getModelDimensions(ctx, accessToken, collectionID, filterID)
+	if err != nil {
+		log.Error(ctx, "failed to get dimensions", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	filterDims := make([]filter.Dimension, 0, len(dims))
+	for _, dim := range dims {
+		filterDims = append(filterDims, filter.Dimension{
+			Name:       dim.Name,
+			ID:         dim.ID,
+			Label:      dim.Label,
+			IsAreaType: dim.IsAreaType,
+		})
+	}
+
+	details, err := f.DatasetClient.Get(ctx, accessToken, "", collectionID, filterJob.Dataset.DatasetID)
+	if err != nil {
+		log.Error(ctx, "failed to get dataset", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	newer, err := f.getNewerVersion(ctx, accessToken, collectionID, filterJob.Dataset, details, filterDims)
+	if err != nil {
+		log.Error(ctx, "failed to get latest version", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+	if newer == nil {
+		err = &clientErr{errors.New("filter is based on the latest version")}
+		log.Error(ctx, "failed to rebuild filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+	if len(newer.Missing) > 0 {
+		err = &clientErr{fmt.Errorf("dimensions are missing from the latest version: %s", strings.Join(newer.Missing, ", "))}
+		log.Error(ctx, "failed to rebuild filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	newFilterID, _, err := f.FilterClient.CreateFlexibleBlueprint(ctx, accessToken, "", "", collectionID,
+		filterJob.Dataset.DatasetID,
+		newer.Edition,
+		strconv.Itoa(newer.Version),
+		dims,
+		filterJob.PopulationType)
+	if err != nil {
+		log.Error(ctx, "failed to create filter", err, log.Data{
+			"filter_id":  filterID,
+			"dataset_id": filterJob.Dataset.DatasetID,
+			"edition":    newer.Edition,
+			"version":    newer.Version,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	f.recordFilterHistory(w, req, newFilterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", newFilterID), http.StatusMovedPermanently)
+}
+
+// getNewerVersion gets the latest version of a dataset when it is newer than the given one, nil is returned otherwise.
+// The dimensions of the filter which the latest version no longer has are listed by label
+func (f *FilterFlex) getNewerVersion(ctx context.Context, accessToken, collectionID string, ds filter.Dataset, details dataset.DatasetDetails, dims []filter.Dimension) (*mapper.NewerVersion, error) {
+	edition, version, ok := parseLatestVersion(details.Links.LatestVersion)
+	if !ok || (edition == ds.Edition && version <= ds.Version) {
+		return nil, nil
+	}
+
+	latest, err := f.DatasetClient.GetVersion(ctx, accessToken, "", "", collectionID, ds.DatasetID, edition, strconv.Itoa(version))
+	if err != nil {
+		return nil, err
+	}
+
+	return &mapper.NewerVersion{
+		Edition:     edition,
+		Version:     version,
+		ReleaseDate: latest.ReleaseDate,
+		Missing:     missingDimensions(latest.Dimensions, dims),
+	}, nil
+}
+
+// parseLatestVersion gets the edition and version from a link to the latest version of a dataset,
+// e.g. /datasets/{id}/editions/{edition}/versions/{version}
+func parseLatestVersion(link dataset.Link) (string, int, bool) {
+	u, err := url.Parse(link.URL)
+	if err != nil {
+		return "", 0, false
+	}
+
+	var edition, version string
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		switch segments[i] {
+		case "editions":
+			edition = segments[i+1]
+		case "versions":
+			version = segments[i+1]
+		}
+	}
+	if link.ID != "" {
+		version = link.ID
+	}
+
+	v, err := strconv.Atoi(version)
+	if err != nil || edition == "" {
+		return "", 0, false
+	}
+	return edition, v, true
+}
+
+// missingDimensions lists the labels of the dimensions which a version does not have. The area type of a filter
+// can be any of its population type so only an area type dimension is required
+func missingDimensions(versionDims []dataset.VersionDimension, dims []filter.Dimension) []string {
+	ids := make(map[string]bool, len(versionDims))
+	hasAreaType := false
+	for _, dim := range versionDims {
+		ids[dim.ID] = true
+		if helpers.IsBoolPtr(dim.IsAreaType) {
+			hasAreaType = true
+		}
+	}
+
+	missing := []string{}
+	for _, dim := range dims {
+		if isAreaType(dim) {
+			if !hasAreaType {
+				missing = append(missing, dim.Label)
+			}
+			continue
+		}
+		if !ids[dim.ID] {
+			missing = append(missing, dim.Label)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRebuildHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+
+	filterJob := &filter.GetFilterResponse{
+		FilterID:       "12345",
+		PopulationType: "UR",
+		Dataset:        filter.Dataset{DatasetID: "example", Edition: "2021", Version: 1},
+	}
+	areaDim := filter.Dimension{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true)}
+	sexDim := filter.Dimension{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false)}
+	expectedDims := []filter.ModelDimension{
+		{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true), Options: []string{"E06000001"}},
+		{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+	}
+	latestDetails := dataset.DatasetDetails{
+		Links: dataset.Links{
+			LatestVersion: dataset.Link{URL: "http://localhost:22000/datasets/example/editions/2021/versions/2", ID: "2"},
+		},
+	}
+
+	expectFilterDimensions := func(mockFc *MockFilterClient) {
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", gomock.Any()).Return(filter.Dimensions{Items: []filter.Dimension{{Name: "ltla"}, {Name: "sex"}}}, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla").Return(areaDim, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex").Return(sexDim, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", gomock.Any()).Return(filter.DimensionOptions{
+			Items: []filter.DimensionOption{{Option: "E06000001"}},
+		}, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "sex", gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+	}
+
+	Convey("Rebuild filter", t, func() {
+		Convey("When the latest version has every dimension of the filter", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			expectFilterDimensions(mockFc)
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "2", expectedDims, "UR").Return("67890", "", nil)
+
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(latestDetails, nil)
+			mockDc.EXPECT().GetVersion(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "2").Return(dataset.Version{
+				Dimensions: []dataset.VersionDimension{
+					{ID: "ltla", IsAreaType: helpers.ToBoolPtr(true)},
+					{ID: "sex_2a"},
+				},
+			}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runRebuild(ff.Rebuild())
+
+			Convey("Then the user is redirected to the rebuilt filter", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/67890/dimensions")
+			})
+			Convey("Then the new filter is recorded in the history", func() {
+				So(w.Result().Cookies(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("When a dimension of the filter is missing from the latest version", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			expectFilterDimensions(mockFc)
+
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(latestDetails, nil)
+			mockDc.EXPECT().GetVersion(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "2").Return(dataset.Version{
+				Dimensions: []dataset.VersionDimension{{ID: "ltla", IsAreaType: helpers.ToBoolPtr(true)}},
+			}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runRebuild(ff.Rebuild())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the filter is based on the latest version", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+			expectFilterDimensions(mockFc)
+
+			mockDc := NewMockDatasetClient(mockCtrl)
+			mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "example").Return(dataset.DatasetDetails{
+				Links: dataset.Links{LatestVersion: dataset.Link{URL: "/datasets/example/editions/2021/versions/1"}},
+			}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runRebuild(ff.Rebuild())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("When the filter is custom", func() {
+			customJob := *filterJob
+			customJob.Custom = helpers.ToBoolPtr(true)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&customJob, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runRebuild(ff.Rebuild())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func TestParseLatestVersion(t *testing.T) {
+	Convey("Given a link to the latest version of a dataset", t, func() {
+		Convey("Then the edition and version are parsed from it", func() {
+			edition, version, ok := parseLatestVersion(dataset.Link{URL: "http://localhost:22000/datasets/example/editions/2022/versions/3"})
+			So(ok, ShouldBeTrue)
+			So(edition, ShouldEqual, "2022")
+			So(version, ShouldEqual, 3)
+		})
+
+		Convey("Then an empty link is not parsed", func() {
+			_, _, ok := parseLatestVersion(dataset.Link{})
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func runRebuild(handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/filters/12345/rebuild", nil)
+	w := httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/rebuild", handler)
+	router.ServeHTTP(w, req)
+	return w
+}
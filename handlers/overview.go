@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"sort"
@@ -8,6 +9,7 @@ import (
 	"sync"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
@@ -31,17 +33,135 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 	vars := mux.Vars(req)
 	filterID := vars["filterID"]
 
+	data, err := f.getOverviewData(ctx, accessToken, collectionID, lang, filterID)
+	if err != nil {
+		setStatusCode(req, w, err)
+		return
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, data.eb, lang, data.serviceMsg, filterID)
+	m.SetDatasetContext(data.datasetContext)
+	overview := m.CreateFilterFlexOverview(*data.filterJob, data.dims, data.dimDescriptions, data.pop, *data.sdc, data.suggestions, data.isMultivariate)
+	f.recordFilterHistory(w, req, filterID, false)
+	f.Render.BuildPage(w, overview, "overview")
+}
+
+// overviewData represents the data gathered for the overview of a filter, which its summary is also built from
+type overviewData struct {
+	filterJob       *filter.GetFilterResponse
+	datasetContext  mapper.DatasetContext
+	datasetDetails  *dataset.DatasetDetails
+	eb              zebedee.EmergencyBanner
+	serviceMsg      string
+	pop             population.GetPopulationTypeResponse
+	dimDescriptions population.GetDimensionsResponse
+	dims            []model.FilterDimension
+	dimIDs          []string
+	areaTypeID      string
+	parent          string
+	areaOpts        []string
+	areas           []population.Area
+	categories      map[string][]population.DimensionCategoryItem
+	sdc             *cantabular.GetBlockedAreaCountResult
+	suggestions     []model.SDCSuggestion
+	isMultivariate  bool
+}
+
+// getOverviewData gets the data of the filter with the descriptions and categorisations of its dimensions, the
+// disclosure control suggestions and any newer version of its dataset. Errors are logged and returned
+func (f *FilterFlex) getOverviewData(ctx context.Context, accessToken, collectionID, lang, filterID string) (*overviewData, error) {
+	data, err := f.getFilterData(ctx, accessToken, collectionID, lang, filterID)
+	if err != nil {
+		return nil, err
+	}
+	filterJob := data.filterJob
+
+	var dErr error
+	dimCategorisations := make(map[string]population.GetCategorisationsResponse)
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		data.dimDescriptions, dErr = f.PopulationClient.GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: accessToken,
+			},
+			PopulationType: filterJob.PopulationType,
+			DimensionIDs:   data.dimIDs,
+		})
+	}()
+
+	go func() {
+		defer wg.Done()
+		for i, dim := range data.dims {
+			if isAreaType(dim.Dimension) {
+				continue
+			}
+			cats, _ := f.PopulationClient.GetCategorisations(ctx, population.GetCategorisationsInput{
+				AuthTokens: population.AuthTokens{
+					UserAuthToken: accessToken,
+				},
+				PaginationParams: population.PaginationParams{
+					Limit: 1000,
+				},
+				PopulationType: filterJob.PopulationType,
+				Dimension:      dim.Name,
+			})
+			dimCategorisations[dim.Name] = cats
+			data.dims[i].CategorisationCount = cats.PaginationResponse.TotalCount
+		}
+	}()
+
+	wg.Wait()
+
+	if dErr != nil {
+		log.Error(ctx, "failed to get dimension descriptions", dErr, log.Data{
+			"population_type": filterJob.PopulationType,
+			"dimension_ids":   data.dimIDs,
+		})
+		return nil, dErr
+	}
+
+	if data.isMultivariate && data.sdc.Blocked > 0 {
+		data.suggestions = f.getSDCSuggestions(ctx, accessToken, filterJob.PopulationType, data.areaTypeID, data.parent, data.dimIDs, data.areaOpts, data.dims, data.categories, dimCategorisations, data.sdc)
+	}
+
+	// log the error but don't set a server error as the filter can be used without rebuilding it
+	if !helpers.IsBoolPtr(filterJob.Custom) && data.datasetDetails != nil {
+		dims := make([]filter.Dimension, 0, len(data.dims))
+		for _, dim := range data.dims {
+			dims = append(dims, dim.Dimension)
+		}
+		newer, err := f.getNewerVersion(ctx, accessToken, collectionID, filterJob.Dataset, *data.datasetDetails, dims)
+		if err != nil {
+			log.Error(ctx, "failed to get latest version", err, log.Data{
+				"filter_id":  filterID,
+				"dataset_id": filterJob.Dataset.DatasetID,
+			})
+		}
+		data.datasetContext.Newer = newer
+	}
+
+	return data, nil
+}
+
+// getFilterData gets the filter with its dimensions, options and disclosure control result, which is all the summary
+// of a filter needs. Errors are logged and returned
+func (f *FilterFlex) getFilterData(ctx context.Context, accessToken, collectionID, lang, filterID string) (*overviewData, error) {
 	var filterDims filter.Dimensions
-	var dimDescriptions population.GetDimensionsResponse
 	var dimCategories population.GetDimensionCategoriesResponse
 	var filterJob *filter.GetFilterResponse
+	var datasetContext mapper.DatasetContext
+	var datasetDetails *dataset.DatasetDetails
 	var eb zebedee.EmergencyBanner
 	var pop population.GetPopulationTypeResponse
 	var sdc *cantabular.GetBlockedAreaCountResult
-	var fErr, dErr, fdsErr, imErr, zErr, sErr, dcErr, pErr error
+	var fErr, fdsErr, imErr, zErr, sErr, dcErr, pErr error
 	var isMultivariate bool
 	var serviceMsg, areaTypeID, parent string
 	var dimIds, nonAreaIds, areaOpts []string
+	var areas []population.Area
 
 	var wg sync.WaitGroup
 	wg.Add(3)
@@ -62,29 +182,28 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 		}
 		filterJob, fErr = f.FilterClient.GetFilter(ctx, *filterInput)
 		if fErr != nil {
-			log.Error(ctx, "failed to get filter", fErr, log.Data{"filter_id": filterID})
-			setStatusCode(req, w, fErr)
 			return
 		}
 
-		if f.EnableMultivariate {
-			isMultivariate, imErr = isMultivariateDataset(ctx, f.DatasetClient, accessToken, collectionID, filterJob.Dataset.DatasetID)
-			if imErr != nil {
-				log.Error(ctx, "failed to determine if dataset type is multivariate", imErr, log.Data{
-					"filter_id": filterID,
-				})
-				setStatusCode(req, w, imErr)
+		// the dataset is got once for its type, the dataset context and its latest version
+		details, err := f.DatasetClient.Get(ctx, accessToken, "", collectionID, filterJob.Dataset.DatasetID)
+		if err != nil {
+			if f.EnableMultivariate {
+				imErr = fmt.Errorf("failed to get dataset: %w", err)
 				return
 			}
+			log.Error(ctx, "failed to get dataset", err, log.Data{"dataset_id": filterJob.Dataset.DatasetID})
+		} else {
+			datasetDetails = &details
+			isMultivariate = f.EnableMultivariate && isMultivariateType(details)
 		}
+		datasetContext = f.getDatasetContextWithDetails(ctx, accessToken, collectionID, filterJob.Dataset, helpers.IsBoolPtr(filterJob.Custom), &details)
 	}()
 
 	go func() {
 		defer wg.Done()
 		filterDims, _, fdsErr = f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
 		if fdsErr != nil {
-			log.Error(ctx, "failed to get dimensions", fdsErr, log.Data{"filter_id": filterID})
-			setStatusCode(req, w, fdsErr)
 			return
 		}
 
@@ -104,23 +223,20 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 	}
 	if fErr != nil {
 		log.Error(ctx, "failed to get filter", fErr, log.Data{"filter_id": filterID})
-		setStatusCode(req, w, fErr)
-		return
+		return nil, fErr
 	}
 	if fdsErr != nil {
 		log.Error(ctx, "failed to get dimensions", fdsErr, log.Data{"filter_id": filterID})
-		setStatusCode(req, w, fdsErr)
-		return
+		return nil, fdsErr
 	}
 	if imErr != nil {
 		log.Error(ctx, "failed to determine if dataset type is multivariate", imErr, log.Data{
 			"filter_id": filterID,
 		})
-		setStatusCode(req, w, imErr)
-		return
+		return nil, imErr
 	}
 
-	wg.Add(3)
+	wg.Add(2)
 	go func() {
 		defer wg.Done()
 		pop, pErr = f.PopulationClient.GetPopulationType(ctx, population.GetPopulationTypeInput{
@@ -150,25 +266,6 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 		}
 	}()
 
-	go func() {
-		defer wg.Done()
-		dimDescriptions, dErr = f.PopulationClient.GetDimensionsDescription(ctx, population.GetDimensionsDescriptionInput{
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: accessToken,
-			},
-			PopulationType: filterJob.PopulationType,
-			DimensionIDs:   dimIds,
-		})
-		if dErr != nil {
-			log.Error(ctx, "failed to get dimension descriptions", dErr, log.Data{
-				"population_type": filterJob.PopulationType,
-				"dimension_ids":   dimIds,
-			})
-			setStatusCode(req, w, dErr)
-			return
-		}
-	}()
-
 	wg.Wait()
 
 	if pErr != nil {
@@ -176,57 +273,46 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 			"filter_id":       filterID,
 			"population_type": filterJob.PopulationType,
 		})
-		setStatusCode(req, w, pErr)
-		return
-	}
-
-	if dErr != nil {
-		log.Error(ctx, "failed to get dimension descriptions", dErr, log.Data{
-			"population_type": filterJob.PopulationType,
-			"dimension_ids":   dimIds,
-		})
-		setStatusCode(req, w, dErr)
-		return
+		return nil, pErr
 	}
 
 	if dcErr != nil {
-		log.Error(ctx, "failed to get dimension categories", dErr, log.Data{
+		log.Error(ctx, "failed to get dimension categories", dcErr, log.Data{
 			"population_type": filterJob.PopulationType,
 			"dimension_ids":   dimIds,
 		})
-		setStatusCode(req, w, dcErr)
-		return
+		return nil, dcErr
 	}
 	dimensionCategoriesMap := mapDimensionCategories(dimCategories)
 
+	categories := map[string][]population.DimensionCategoryItem{}
 	getDimensionOptions := func(dim filter.Dimension) ([]string, int, error) {
 		dimensionCategory := dimensionCategoriesMap[dim.ID]
 
+		// options of a variable are the chosen subset of its categories, all categories are included when there are none
+		opts, err := getAllDimensionOptions(ctx, f.FilterClient, accessToken, collectionID, filterID, dim.Name)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to get options for dimension: %w", err)
+		}
+		subset := make(map[string]bool, len(opts.Items))
+		for _, opt := range opts.Items {
+			subset[opt.Option] = true
+		}
+
 		var options []string
 		for _, opt := range sortCategoriesByID(dimensionCategory.Categories) {
+			if len(subset) > 0 && !subset[opt.ID] {
+				continue
+			}
 			options = append(options, opt.Label)
+			categories[dim.Name] = append(categories[dim.Name], opt)
 		}
 
 		return options, len(options), nil
 	}
 
-	getDimensionCategorisations := func(populationType string, dimension string) (int, error) {
-		cats, err := f.PopulationClient.GetCategorisations(ctx, population.GetCategorisationsInput{
-			AuthTokens: population.AuthTokens{
-				UserAuthToken: accessToken,
-			},
-			PaginationParams: population.PaginationParams{
-				Limit: 1000,
-			},
-			PopulationType: populationType,
-			Dimension:      dimension,
-		})
-		return cats.PaginationResponse.TotalCount, err
-	}
-
 	getAreaOptions := func(dim filter.Dimension) ([]string, int, error) {
-		q := filter.QueryParams{Offset: 0, Limit: 500}
-		opts, _, err := f.FilterClient.GetDimensionOptions(ctx, accessToken, "", collectionID, filterID, dim.Name, &q)
+		opts, err := getAllDimensionOptions(ctx, f.FilterClient, accessToken, collectionID, filterID, dim.Name)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to get options for dimension: %w", err)
 		}
@@ -250,46 +336,30 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 			return options, areas.TotalCount, nil
 		}
 
-		var wg sync.WaitGroup
-		totalCount := opts.TotalCount
-		optsIDs := []string{}
+		areaTypeID := dim.ID
+		if dim.FilterByParent != "" {
+			areaTypeID = dim.FilterByParent
+		}
+		optsIDs := make([]string, 0, len(opts.Items))
 		for _, opt := range opts.Items {
-			wg.Add(1)
-			go func(opt filter.DimensionOption) {
-				defer wg.Done()
-				optsIDs = append(optsIDs, opt.Option)
-				var areaTypeID string
-				if dim.FilterByParent != "" {
-					areaTypeID = dim.FilterByParent
-				} else {
-					areaTypeID = dim.ID
-				}
-
-				area, err := f.PopulationClient.GetArea(ctx, population.GetAreaInput{
-					AuthTokens: population.AuthTokens{
-						UserAuthToken: accessToken,
-					},
-					PopulationType: filterJob.PopulationType,
-					AreaType:       areaTypeID,
-					Area:           opt.Option,
-				})
-				if err != nil {
-					log.Error(ctx, "failed to get area", err, log.Data{
-						"area": dim.ID,
-						"ID":   opt.Option,
-					})
-					setStatusCode(req, w, err)
-					return
-				}
-
-				options = append(options, area.Area.Label)
-			}(opt)
+			optsIDs = append(optsIDs, opt.Option)
+		}
+		selected, err := f.getAreasByID(ctx, accessToken, filterJob.PopulationType, areaTypeID, optsIDs)
+		if err != nil {
+			return nil, 0, err
 		}
-		wg.Wait()
 
+		// an area which is not found is listed by its code
+		for i, id := range optsIDs {
+			if selected[i].ID == "" {
+				selected[i] = population.Area{ID: id, Label: id}
+			}
+			options = append(options, selected[i].Label)
+		}
 		areaOpts = optsIDs
+		areas = selected
 
-		return options, totalCount, nil
+		return options, opts.TotalCount, nil
 	}
 
 	getOptions := func(dim filter.Dimension) ([]string, int, error) {
@@ -303,34 +373,28 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 	}
 
 	var fDims []model.FilterDimension
-	for i := len(filterDims.Items) - 1; i >= 0; i-- {
+	for i := range filterDims.Items {
 		// Needed to determine whether dimension is_area_type
 		filterDimension, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterID, filterDims.Items[i].Name)
 		if err != nil {
 			log.Error(ctx, "failed to get dimension", err, log.Data{"dimension_name": filterDims.Items[i].Name})
-			setStatusCode(req, w, err)
-			return
+			return nil, err
 		}
 		filterDims.Items[i].IsAreaType = filterDimension.IsAreaType
 		filterDims.Items[i].FilterByParent = filterDimension.FilterByParent
+		filterDims.Items[i].QualityStatementText = filterDimension.QualityStatementText
+		filterDims.Items[i].QualitySummaryURL = filterDimension.QualitySummaryURL
 
 		options, count, err := getOptions(filterDims.Items[i])
 		if err != nil {
 			log.Error(ctx, "failed to get options for dimension", err, log.Data{"dimension_name": filterDims.Items[i].Name})
-			setStatusCode(req, w, err)
-			return
-		}
-
-		categorisationCount := 0
-		if !isAreaType(filterDimension) {
-			categorisationCount, _ = getDimensionCategorisations(filterJob.PopulationType, filterDimension.Name)
+			return nil, err
 		}
 
 		filterDims.Items[i].Options = options
 		fDims = append(fDims, model.FilterDimension{
-			Dimension:           filterDims.Items[i],
-			OptionsCount:        count,
-			CategorisationCount: categorisationCount,
+			Dimension:    filterDims.Items[i],
+			OptionsCount: count,
 		})
 	}
 
@@ -343,17 +407,29 @@ func filterFlexOverview(w http.ResponseWriter, req *http.Request, f *FilterFlex,
 				"area_codes":      areaOpts,
 				"area_type_id":    areaTypeID,
 			})
-			setStatusCode(req, w, sErr)
-			return
+			return nil, sErr
 		}
 	} else {
 		sdc = &cantabular.GetBlockedAreaCountResult{}
 	}
 
-	basePage := f.Render.NewBasePageModel()
-	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
-	overview := m.CreateFilterFlexOverview(*filterJob, fDims, dimDescriptions, pop, *sdc, isMultivariate)
-	f.Render.BuildPage(w, overview, "overview")
+	return &overviewData{
+		filterJob:      filterJob,
+		datasetContext: datasetContext,
+		datasetDetails: datasetDetails,
+		eb:             eb,
+		serviceMsg:     serviceMsg,
+		pop:            pop,
+		dims:           fDims,
+		dimIDs:         dimIds,
+		areaTypeID:     areaTypeID,
+		parent:         parent,
+		areaOpts:       areaOpts,
+		areas:          areas,
+		categories:     categories,
+		sdc:            sdc,
+		isMultivariate: isMultivariate,
+	}, nil
 }
 
 func mapDimensionCategories(dimCategories population.GetDimensionCategoriesResponse) map[string]population.DimensionCategory {
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"errors"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -13,6 +14,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 	gomock "github.com/golang/mock/gomock"
@@ -70,6 +72,7 @@ func TestOverviewHandler(t *testing.T) {
 				mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{}, nil)
 				mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
 				mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims.Items[0], "", nil)
+				mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
 				mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.DatasetDetails{}, nil)
 				mockZc := NewMockZebedeeClient(mockCtrl)
 				mockZc.
@@ -101,7 +104,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -109,6 +113,61 @@ func TestOverviewHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusOK)
 			})
 
+			Convey("the dataset is got once for its type, context and latest version", func() {
+				mockRend := NewMockRenderClient(mockCtrl)
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockPc := NewMockPopulationClient(mockCtrl)
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockFilterDims := filter.Dimensions{
+					Items: []filter.Dimension{
+						{
+							Name:       "Test",
+							IsAreaType: new(bool),
+							Options:    []string{"an option", "and another"},
+						},
+					},
+				}
+				var overview model.Overview
+				mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+				mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "overview").Do(func(w io.Writer, m interface{}, _ string) {
+					overview = m.(model.Overview)
+				})
+				mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{
+					Dataset: filter.Dataset{DatasetID: "dataset-id", Edition: "2021", Version: 1},
+				}, nil)
+				mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+				mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims.Items[0], "", nil)
+				mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+				mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "dataset-id").Return(dataset.DatasetDetails{
+					Title: "Dataset title",
+					Links: dataset.Links{LatestVersion: dataset.Link{URL: "/datasets/dataset-id/editions/2021/versions/2"}},
+				}, nil).Times(1)
+				mockDc.EXPECT().GetVersion(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.Version{Version: 2}, nil).AnyTimes()
+				mockZc.EXPECT().GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+				mockPc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{
+					PaginationResponse: population.PaginationResponse{TotalCount: 2},
+				}, nil).AnyTimes()
+				mockPc.EXPECT().GetDimensionsDescription(ctx, gomock.Any()).Return(population.GetDimensionsResponse{}, nil)
+				mockPc.EXPECT().GetDimensionCategories(ctx, gomock.Any()).Return(population.GetDimensionCategoriesResponse{
+					PaginationResponse: population.PaginationResponse{TotalCount: 1},
+					Categories:         mockDimensionCategories,
+				}, nil).AnyTimes()
+				mockPc.EXPECT().GetPopulationType(ctx, gomock.Any()).Return(population.GetPopulationTypeResponse{}, nil)
+
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
+
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
+				router.ServeHTTP(w, req)
+
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(overview.DatasetContext.Title, ShouldEqual, "Dataset title")
+				So(overview.NewerVersion.Version, ShouldEqual, "2")
+			})
+
 			Convey("when the zebedee.GetHomepageContent api method responds with an error", func() {
 				mockRend := NewMockRenderClient(mockCtrl)
 				mockDc := NewMockDatasetClient(mockCtrl)
@@ -129,6 +188,7 @@ func TestOverviewHandler(t *testing.T) {
 				mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{}, nil)
 				mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
 				mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims.Items[0], "", nil)
+				mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
 				mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.DatasetDetails{}, nil)
 				mockZc := NewMockZebedeeClient(mockCtrl)
 				mockZc.
@@ -158,7 +218,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 
@@ -180,6 +241,7 @@ func TestOverviewHandler(t *testing.T) {
 				mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{}, nil)
 				mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dims, "", nil)
 				mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dims.Items[0], "", nil)
+				mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
 				mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.DatasetDetails{}, nil)
 				mockZc := NewMockZebedeeClient(mockCtrl)
 				mockZc.
@@ -209,7 +271,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 
@@ -218,6 +281,73 @@ func TestOverviewHandler(t *testing.T) {
 				So(w.Code, ShouldEqual, http.StatusOK)
 			})
 
+			Convey("category subset on filter job only the subset is displayed", func() {
+				mockRend := NewMockRenderClient(mockCtrl)
+				mockDc := NewMockDatasetClient(mockCtrl)
+				mockFc := NewMockFilterClient(mockCtrl)
+				mockPc := NewMockPopulationClient(mockCtrl)
+				variable := filter.Dimension{
+					Name:       "resident_age_3a",
+					ID:         "resident_age_3a",
+					IsAreaType: new(bool),
+				}
+
+				var overview model.Overview
+				mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+				mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "overview").Do(func(_ interface{}, page interface{}, _ string) {
+					overview = page.(model.Overview)
+				})
+				mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{}, nil)
+				mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(filter.Dimensions{Items: []filter.Dimension{variable}}, "", nil)
+				mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(variable, "", nil)
+				mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), variable.Name, gomock.Any()).Return(filter.DimensionOptions{
+					Items:      []filter.DimensionOption{{Option: "1"}, {Option: "3"}},
+					TotalCount: 2,
+				}, "", nil)
+				mockDc.EXPECT().Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(dataset.DatasetDetails{}, nil)
+				mockZc := NewMockZebedeeClient(mockCtrl)
+				mockZc.
+					EXPECT().
+					GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(zebedee.HomepageContent{}, nil)
+				mockPc.EXPECT().GetCategorisations(ctx, gomock.Any()).Return(population.GetCategorisationsResponse{}, nil)
+				mockPc.
+					EXPECT().
+					GetDimensionsDescription(ctx, gomock.Any()).
+					Return(population.GetDimensionsResponse{}, nil)
+				mockPc.EXPECT().GetDimensionCategories(ctx, gomock.Any()).
+					Return(population.GetDimensionCategoriesResponse{
+						Categories: []population.DimensionCategory{
+							{
+								Id: variable.ID,
+								Categories: []population.DimensionCategoryItem{
+									{ID: "1", Label: "Aged 15 years and under"},
+									{ID: "2", Label: "Aged 16 to 64 years"},
+									{ID: "3", Label: "Aged 65 years and over"},
+								},
+							},
+						},
+					}, nil)
+				mockPc.
+					EXPECT().
+					GetPopulationType(ctx, gomock.Any()).
+					Return(population.GetPopulationTypeResponse{}, nil)
+
+				w := httptest.NewRecorder()
+				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
+
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
+				router := mux.NewRouter()
+				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
+				router.ServeHTTP(w, req)
+
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(overview.Dimensions[3].Options, ShouldResemble, []string{"Aged 15 years and under", "Aged 65 years and over"})
+				So(overview.Dimensions[3].OptionsCount, ShouldEqual, 2)
+				So(overview.Dimensions[3].CategoriesURI, ShouldEqual, "/filters/12345/dimensions/resident_age_3a/categories")
+			})
+
 			Convey("Given an area type dimension", func() {
 				Convey("When the dimensions API responds with an error", func() {
 					filterDim := filter.Dimension{
@@ -253,10 +383,6 @@ func TestOverviewHandler(t *testing.T) {
 						EXPECT().
 						GetAreas(ctx, gomock.Any()).
 						Return(population.GetAreasResponse{}, errors.New("internal error"))
-					mockPc.
-						EXPECT().
-						GetDimensionsDescription(ctx, gomock.Any()).
-						Return(population.GetDimensionsResponse{}, nil)
 					mockPc.
 						EXPECT().
 						GetDimensionCategories(ctx, gomock.Any()).
@@ -291,7 +417,8 @@ func TestOverviewHandler(t *testing.T) {
 					w := httptest.NewRecorder()
 					req := httptest.NewRequest(http.MethodGet, "/", nil)
 
-					ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, cfg)
+					expectDatasetContext(mockDc)
+					ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 					ff.FilterFlexOverview().
 						ServeHTTP(w, req)
 
@@ -390,7 +517,8 @@ func TestOverviewHandler(t *testing.T) {
 						w := httptest.NewRecorder()
 						req := httptest.NewRequest(http.MethodGet, "/", nil)
 
-						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+						expectDatasetContext(mockDc)
+						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 						ff.FilterFlexOverview().
 							ServeHTTP(w, req)
 
@@ -485,7 +613,8 @@ func TestOverviewHandler(t *testing.T) {
 						w := httptest.NewRecorder()
 						req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+						expectDatasetContext(mockDc)
+						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 						ff.FilterFlexOverview().
 							ServeHTTP(w, req)
 
@@ -593,7 +722,8 @@ func TestOverviewHandler(t *testing.T) {
 						w := httptest.NewRecorder()
 						req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+						expectDatasetContext(mockDc)
+						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 						ff.FilterFlexOverview().
 							ServeHTTP(w, req)
 
@@ -709,7 +839,8 @@ func TestOverviewHandler(t *testing.T) {
 						w := httptest.NewRecorder()
 						req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+						expectDatasetContext(mockDc)
+						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 						ff.FilterFlexOverview().
 							ServeHTTP(w, req)
 
@@ -825,7 +956,8 @@ func TestOverviewHandler(t *testing.T) {
 						w := httptest.NewRecorder()
 						req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+						expectDatasetContext(mockDc)
+						ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 						ff.FilterFlexOverview().
 							ServeHTTP(w, req)
 
@@ -876,7 +1008,7 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, NewMockDatasetClient(mockCtrl), mockPc, mockZc, cfg)
+				ff := NewFilterFlex(mockRend, mockFc, NewMockDatasetClient(mockCtrl), mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -926,7 +1058,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 
@@ -957,6 +1090,14 @@ func TestOverviewHandler(t *testing.T) {
 					EXPECT().
 					GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(mockFilterDims, "", nil)
+				mockFc.
+					EXPECT().
+					GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(mockFilterDims.Items[0], "", nil)
+				mockFc.
+					EXPECT().
+					GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
 				mockDc.
 					EXPECT().
 					Get(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
@@ -994,7 +1135,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -1053,10 +1195,6 @@ func TestOverviewHandler(t *testing.T) {
 					EXPECT().
 					GetArea(ctx, gomock.Any()).
 					Return(area, nil)
-				mockPc.
-					EXPECT().
-					GetDimensionsDescription(ctx, gomock.Any()).
-					Return(population.GetDimensionsResponse{}, nil)
 				mockPc.
 					EXPECT().
 					GetBlockedAreaCount(gomock.Any(), gomock.Any()).
@@ -1099,7 +1237,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest(http.MethodGet, "/test", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				ff.FilterFlexOverview().
 					ServeHTTP(w, req)
 
@@ -1151,7 +1290,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -1198,10 +1338,6 @@ func TestOverviewHandler(t *testing.T) {
 						PaginationResponse: population.PaginationResponse{TotalCount: 1},
 						Categories:         mockDimensionCategories,
 					}, nil).AnyTimes()
-				mockPc.
-					EXPECT().
-					GetDimensionsDescription(ctx, gomock.Any()).
-					Return(population.GetDimensionsResponse{}, nil)
 				mockPc.EXPECT().
 					GetCategorisations(ctx, gomock.Any()).
 					Return(population.GetCategorisationsResponse{
@@ -1231,7 +1367,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -1273,10 +1410,6 @@ func TestOverviewHandler(t *testing.T) {
 						PaginationResponse: population.PaginationResponse{TotalCount: 1},
 						Categories:         mockDimensionCategories,
 					}, nil).AnyTimes()
-				mockPc.
-					EXPECT().
-					GetDimensionsDescription(gomock.Any(), gomock.Any()).
-					Return(population.GetDimensionsResponse{}, nil)
 				mockPc.
 					EXPECT().
 					GetPopulationType(ctx, gomock.Any()).
@@ -1291,7 +1424,8 @@ func TestOverviewHandler(t *testing.T) {
 				w := httptest.NewRecorder()
 				req := httptest.NewRequest("GET", "/filters/12345/dimensions", nil)
 
-				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, cfg)
+				expectDatasetContext(mockDc)
+				ff := NewFilterFlex(mockRend, mockFc, mockDc, mockPc, mockZc, testGeography, cfg)
 				router := mux.NewRouter()
 				router.HandleFunc("/filters/12345/dimensions", ff.FilterFlexOverview())
 				router.ServeHTTP(w, req)
@@ -0,0 +1,383 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// populationTypeChange represents a change of the population type of a filter with the dimensions of the rebuilt filter
+type populationTypeChange struct {
+	mapper.PopulationTypeChange
+	Dimensions []filter.ModelDimension
+}
+
+// PopulationTypeSelector Handler
+func (f *FilterFlex) PopulationTypeSelector() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		populationTypeSelector(w, req, f, collectionID, accessToken, lang)
+	})
+}
+
+func populationTypeSelector(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken, lang string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	selected := req.URL.Query().Get("population_type")
+
+	logData := log.Data{
+		"filter_id":       filterID,
+		"population_type": selected,
+	}
+
+	eb, serviceMsg, err := getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	// log zebedee error but don't set a server error
+	if err != nil {
+		log.Error(ctx, "unable to get homepage content", err, log.Data{"homepage_content": err})
+	}
+
+	filterJob, err := f.getPopulationTypeFilter(ctx, accessToken, collectionID, filterID)
+	if err != nil {
+		log.Error(ctx, "failed to get filter of population type change", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	current, alternatives, err := f.getPopulationTypes(ctx, accessToken, filterJob.PopulationType)
+	if err != nil {
+		log.Error(ctx, "failed to get population types", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	var review *mapper.PopulationTypeChange
+	if selected != "" {
+		change, err := f.getPopulationTypeChange(ctx, accessToken, collectionID, filterJob, alternatives, selected)
+		if err != nil {
+			log.Error(ctx, "failed to get population type change", err, logData)
+			setStatusCode(req, w, err)
+			return
+		}
+		review = &change.PopulationTypeChange
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
+	m.SetDatasetContext(f.getDatasetContext(ctx, accessToken, collectionID, filterJob.Dataset, true))
+	selector := m.CreatePopulationTypeSelector(current, alternatives, review)
+	f.Render.BuildPage(w, selector, "population-type")
+}
+
+// ChangePopulationType Handler
+func (f *FilterFlex) ChangePopulationType() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		changePopulationType(w, req, f, collectionID, accessToken)
+	})
+}
+
+func changePopulationType(w http.ResponseWriter, req *http.Request, f *FilterFlex, collectionID, accessToken string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+
+	logData := log.Data{
+		"filter_id": filterID,
+	}
+
+	if err := req.ParseForm(); err != nil {
+		log.Error(ctx, "failed to parse change population type form", err, logData)
+		setStatusCode(req, w, fmt.Errorf("error parsing form: %w", err))
+		return
+	}
+	logData["population_type"] = req.FormValue("population_type")
+
+	filterJob, err := f.getPopulationTypeFilter(ctx, accessToken, collectionID, filterID)
+	if err != nil {
+		log.Error(ctx, "failed to get filter of population type change", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	_, alternatives, err := f.getPopulationTypes(ctx, accessToken, filterJob.PopulationType)
+	if err != nil {
+		log.Error(ctx, "failed to get population types", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	change, err := f.getPopulationTypeChange(ctx, accessToken, collectionID, filterJob, alternatives, req.FormValue("population_type"))
+	if err != nil {
+		log.Error(ctx, "failed to get population type change", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// the population type of a filter cannot be updated, the filter is rebuilt for the new population type instead
+	newFilterID, _, err := f.FilterClient.CreateFlexibleBlueprintCustom(ctx, accessToken, "", "", filter.CreateFlexBlueprintCustomRequest{
+		Dataset:        filterJob.Dataset,
+		Dimensions:     change.Dimensions,
+		PopulationType: change.PopulationType.Name,
+		CollectionID:   collectionID,
+	})
+	if err != nil {
+		log.Error(ctx, "failed to create filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	f.recordFilterHistory(w, req, newFilterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", newFilterID), http.StatusMovedPermanently)
+}
+
+// getPopulationTypeFilter gets the filter whose population type is changed, only the population type of custom filters can be changed
+func (f *FilterFlex) getPopulationTypeFilter(ctx context.Context, accessToken, collectionID, filterID string) (*filter.GetFilterResponse, error) {
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !helpers.IsBoolPtr(filterJob.Custom) {
+		return nil, &clientErr{errors.New("population type can only be changed for custom filters")}
+	}
+	return filterJob, nil
+}
+
+// getPopulationTypes returns the current population type and the population types of the same type which can replace it
+func (f *FilterFlex) getPopulationTypes(ctx context.Context, accessToken, name string) (current population.PopulationType, alternatives []population.PopulationType, err error) {
+	populationTypes, err := f.PopulationClient.GetPopulationTypes(ctx, population.GetPopulationTypesInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+	})
+	if err != nil {
+		return current, nil, fmt.Errorf("failed to get population types: %w", err)
+	}
+
+	current = population.PopulationType{Name: name, Label: name}
+	for _, populationType := range populationTypes.Items {
+		if populationType.Name == name {
+			current = populationType
+		}
+	}
+	for _, populationType := range populationTypes.Items {
+		if populationType.Name != name && populationType.Type == current.Type {
+			alternatives = append(alternatives, populationType)
+		}
+	}
+	return current, alternatives, nil
+}
+
+// getPopulationTypeChange validates the change of the population type of the filter and rebuilds its dimensions for the new
+// population type. Variables and areas of the coverage which are not available are dropped and an area type which is not
+// available is replaced by the highest area type allowed, with the default coverage
+func (f *FilterFlex) getPopulationTypeChange(ctx context.Context, accessToken, collectionID string, filterJob *filter.GetFilterResponse, alternatives []population.PopulationType, name string) (populationTypeChange, error) {
+	if name == "" {
+		return populationTypeChange{}, &clientErr{errors.New("missing required value 'population_type'")}
+	}
+	var change populationTypeChange
+	for _, populationType := range alternatives {
+		if populationType.Name == name {
+			change.PopulationType = populationType
+		}
+	}
+	if change.PopulationType.Name == "" {
+		return populationTypeChange{}, &clientErr{fmt.Errorf("population type %q is not allowed", name)}
+	}
+
+	areaTypes, err := f.PopulationClient.GetAreaTypes(ctx, population.GetAreaTypesInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: name,
+	})
+	if err != nil {
+		return populationTypeChange{}, fmt.Errorf("failed to get population area types: %w", err)
+	}
+	lowest, highest := f.Geography.Limits(filterJob.Dataset.DatasetID, name, true)
+	allowed := rangeAreaTypes(areaTypes.AreaTypes, lowest, highest)
+	if len(allowed) == 0 {
+		return populationTypeChange{}, fmt.Errorf("no area types allowed for population type %s", name)
+	}
+
+	filterDims, _, err := f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterJob.FilterID, &filter.QueryParams{Limit: 500})
+	if err != nil {
+		return populationTypeChange{}, fmt.Errorf("failed to get dimensions: %w", err)
+	}
+
+	for _, fd := range filterDims.Items {
+		// Needed to determine whether dimension is_area_type and filter_by_parent
+		dim, _, err := f.FilterClient.GetDimension(ctx, accessToken, "", collectionID, filterJob.FilterID, fd.Name)
+		if err != nil {
+			return populationTypeChange{}, fmt.Errorf("failed to get dimension %s: %w", fd.Name, err)
+		}
+
+		if isAreaType(dim) {
+			modelDim, err := f.mapAreaTypeDimension(ctx, accessToken, collectionID, filterJob, dim, allowed, &change)
+			if err != nil {
+				return populationTypeChange{}, err
+			}
+			change.Dimensions = append(change.Dimensions, modelDim)
+			continue
+		}
+
+		available, err := f.isVariableAvailable(ctx, accessToken, name, dim)
+		if err != nil {
+			return populationTypeChange{}, err
+		}
+		if !available {
+			change.Dropped = append(change.Dropped, dim)
+			continue
+		}
+		options, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterJob.FilterID, dim.Name)
+		if err != nil {
+			return populationTypeChange{}, fmt.Errorf("failed to get options for dimension %s: %w", dim.Name, err)
+		}
+		change.Kept = append(change.Kept, dim)
+		change.Dimensions = append(change.Dimensions, filter.ModelDimension{
+			Name:                 dim.Name,
+			ID:                   dim.ID,
+			Label:                dim.Label,
+			IsAreaType:           helpers.ToBoolPtr(false),
+			Options:              options,
+			QualityStatementText: dim.QualityStatementText,
+			QualitySummaryURL:    dim.QualitySummaryURL,
+		})
+	}
+	return change, nil
+}
+
+// mapAreaTypeDimension keeps the area type dimension with the areas of its coverage which exist in the new population type
+// when the area type is allowed, otherwise it is replaced by the highest allowed area type
+func (f *FilterFlex) mapAreaTypeDimension(ctx context.Context, accessToken, collectionID string, filterJob *filter.GetFilterResponse, dim filter.Dimension, allowed []population.AreaType, change *populationTypeChange) (filter.ModelDimension, error) {
+	for _, areaType := range allowed {
+		if areaType.ID != dim.ID {
+			continue
+		}
+		options, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterJob.FilterID, dim.Name)
+		if err != nil {
+			return filter.ModelDimension{}, fmt.Errorf("failed to get options for dimension %s: %w", dim.Name, err)
+		}
+		kept, err := f.getAvailableCoverage(ctx, accessToken, filterJob.PopulationType, dim, options, change)
+		if err != nil {
+			return filter.ModelDimension{}, err
+		}
+		parent := dim.FilterByParent
+		if len(kept) == 0 {
+			parent = ""
+		}
+		change.AreaType = areaType
+		return filter.ModelDimension{
+			Name:                 dim.Name,
+			ID:                   dim.ID,
+			Label:                dim.Label,
+			IsAreaType:           helpers.ToBoolPtr(true),
+			Options:              kept,
+			FilterByParent:       parent,
+			QualityStatementText: dim.QualityStatementText,
+			QualitySummaryURL:    dim.QualitySummaryURL,
+		}, nil
+	}
+
+	change.AreaType = allowed[0]
+	change.IsAreaTypeChanged = true
+	return filter.ModelDimension{
+		Name:       allowed[0].ID,
+		ID:         allowed[0].ID,
+		Label:      allowed[0].Label,
+		IsAreaType: helpers.ToBoolPtr(true),
+		Options:    []string{},
+	}, nil
+}
+
+// isVariableAvailable determines if the categorisation of the variable exists in the population type
+func (f *FilterFlex) isVariableAvailable(ctx context.Context, accessToken, populationType string, dim filter.Dimension) (bool, error) {
+	cats, err := f.PopulationClient.GetCategorisations(ctx, population.GetCategorisationsInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: populationType,
+		Dimension:      dim.Name,
+	})
+	if err != nil {
+		if cErr, ok := err.(ClientError); ok && cErr.Code() == http.StatusNotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to get categorisations of %s: %w", dim.Name, err)
+	}
+	for _, cat := range cats.Items {
+		if cat.ID == dim.ID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// getAvailableCoverage returns the areas of the coverage which exist in the new population type of the change. The areas
+// which do not exist are recorded as dropped, with their labels in the current population type
+func (f *FilterFlex) getAvailableCoverage(ctx context.Context, accessToken, currentPopulationType string, dim filter.Dimension, options []string, change *populationTypeChange) ([]string, error) {
+	kept := []string{}
+	if len(options) == 0 {
+		return kept, nil
+	}
+
+	coverageType := dim.ID
+	if dim.FilterByParent != "" {
+		coverageType = dim.FilterByParent
+	}
+	areas, err := f.getAreasByID(ctx, accessToken, change.PopulationType.Name, coverageType, options)
+	if err != nil {
+		return nil, err
+	}
+
+	var dropped []string
+	for i, area := range areas {
+		if area.ID == "" {
+			dropped = append(dropped, options[i])
+			continue
+		}
+		kept = append(kept, options[i])
+	}
+	if len(dropped) == 0 {
+		return kept, nil
+	}
+
+	log.Warn(ctx, "areas of the coverage are not available for the population type and will be removed", log.Data{
+		"population_type": change.PopulationType.Name,
+		"area_type":       coverageType,
+		"areas":           dropped,
+	})
+	droppedAreas, err := f.getAreasByID(ctx, accessToken, currentPopulationType, coverageType, dropped)
+	if err != nil {
+		return nil, err
+	}
+	for i, area := range droppedAreas {
+		if area.ID == "" {
+			area = population.Area{ID: dropped[i], Label: dropped[i]}
+		}
+		change.DroppedAreas = append(change.DroppedAreas, area)
+	}
+	return kept, nil
+}
@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPopulationTypeHandlers(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	const filterID = "1234"
+
+	customFilter := &filter.GetFilterResponse{
+		FilterID:       filterID,
+		Dataset:        filter.Dataset{DatasetID: "create-my-own", Edition: "2021", Version: 1},
+		PopulationType: "UR",
+		Custom:         helpers.ToBoolPtr(true),
+	}
+	populationTypes := population.GetPopulationTypesResponse{
+		Items: []population.PopulationType{
+			{Name: "UR", Label: "All usual residents", Type: "microdata"},
+			{Name: "UR_CE", Label: "All usual residents in communal establishments", Type: "microdata"},
+			{Name: "HH", Label: "All households", Type: "microdata"},
+			{Name: "AP", Label: "All persons", Type: "aggregate"},
+		},
+	}
+	filterDims := map[string]filter.Dimension{
+		"oa":               {Name: "oa", ID: "oa", Label: "Output areas", IsAreaType: helpers.ToBoolPtr(true)},
+		"sex":              {Name: "sex", ID: "sex", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false), QualitySummaryURL: "/quality"},
+		"resident_age_18b": {Name: "resident_age_18b", ID: "resident_age_18b", Label: "Age (18 categories)", IsAreaType: helpers.ToBoolPtr(false)},
+	}
+	categorisations := map[string][]population.Dimension{
+		"sex": {{ID: "sex"}},
+	}
+
+	newZebedeeClient := func() *MockZebedeeClient {
+		mockZc := NewMockZebedeeClient(mockCtrl)
+		mockZc.
+			EXPECT().
+			GetHomepageContent(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(zebedee.HomepageContent{}, nil)
+		return mockZc
+	}
+	expectFilter := func(mockFc *MockFilterClient, mockPc *MockPopulationClient, filterJob *filter.GetFilterResponse) {
+		mockFc.
+			EXPECT().
+			GetFilter(gomock.Any(), gomock.Any()).
+			Return(filterJob, nil)
+		mockPc.
+			EXPECT().
+			GetPopulationTypes(gomock.Any(), gomock.Any()).
+			Return(populationTypes, nil).
+			AnyTimes()
+	}
+	expectChange := func(mockFc *MockFilterClient, mockPc *MockPopulationClient) {
+		mockPc.
+			EXPECT().
+			GetAreaTypes(gomock.Any(), gomock.Any()).
+			Return(population.GetAreaTypesResponse{
+				AreaTypes: []population.AreaType{
+					{ID: "oa", Label: "Output areas", Hierarchy_Order: 100},
+					{ID: "msoa", Label: "MSOA", Hierarchy_Order: 300},
+					{ID: "ctry", Label: "Country", Hierarchy_Order: 900},
+				},
+			}, nil)
+		mockFc.
+			EXPECT().
+			GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).
+			Return(filter.Dimensions{Items: []filter.Dimension{{Name: "oa"}, {Name: "sex"}, {Name: "resident_age_18b"}}}, "", nil)
+		mockFc.
+			EXPECT().
+			GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).
+			DoAndReturn(func(_, _, _, _, _ interface{}, name string) (filter.Dimension, string, error) {
+				return filterDims[name], "", nil
+			}).
+			Times(3)
+		mockPc.
+			EXPECT().
+			GetCategorisations(gomock.Any(), gomock.Any()).
+			DoAndReturn(func(_ interface{}, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+				items, ok := categorisations[input.Dimension]
+				if !ok {
+					return population.GetCategorisationsResponse{}, &testCliError{}
+				}
+				return population.GetCategorisationsResponse{Items: items}, nil
+			}).
+			Times(2)
+		mockFc.
+			EXPECT().
+			GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "sex", gomock.Any()).
+			Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "1"}}}, "", nil)
+	}
+
+	Convey("Population type selector", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockPc := NewMockPopulationClient(mockCtrl)
+
+		Convey("Given a custom filter", func() {
+			expectFilter(mockFc, mockPc, customFilter)
+
+			var selector model.PopulationTypeSelector
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.
+				EXPECT().
+				BuildPage(gomock.Any(), gomock.Any(), "population-type").
+				Do(func(_ interface{}, page interface{}, _ string) {
+					selector = page.(model.PopulationTypeSelector)
+				})
+
+			Convey("When no population type is selected", func() {
+				ff := NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, newZebedeeClient(), testGeography, cfg)
+				w := runPopulationType(http.MethodGet, "", filterID, nil, ff.PopulationTypeSelector())
+
+				Convey("Then the other population types of the same type are listed", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(selector.PopulationType, ShouldEqual, "All usual residents")
+					So(selector.Selections, ShouldHaveLength, 2)
+					So(selector.Selections[0].Value, ShouldEqual, "UR_CE")
+					So(selector.Selections[1].Value, ShouldEqual, "HH")
+					So(selector.HasReview, ShouldBeFalse)
+				})
+			})
+
+			Convey("When a population type is selected", func() {
+				expectChange(mockFc, mockPc)
+				ff := NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, newZebedeeClient(), testGeography, cfg)
+				w := runPopulationType(http.MethodGet, "population_type=UR_CE", filterID, nil, ff.PopulationTypeSelector())
+
+				Convey("Then the kept and dropped variables and the area type are reviewed", func() {
+					So(w.Code, ShouldEqual, http.StatusOK)
+					So(selector.HasReview, ShouldBeTrue)
+					So(selector.InitialSelection, ShouldEqual, "UR_CE")
+					So(selector.KeptVariables, ShouldResemble, []string{"Sex"})
+					So(selector.DroppedVariables, ShouldResemble, []string{"Age"})
+					So(selector.AreaType, ShouldEqual, "Country")
+					So(selector.IsAreaTypeChanged, ShouldBeTrue)
+				})
+			})
+		})
+
+		Convey("Given a filter which is not custom", func() {
+			expectFilter(mockFc, mockPc, &filter.GetFilterResponse{FilterID: filterID, PopulationType: "UR"})
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, newZebedeeClient(), testGeography, cfg)
+			w := runPopulationType(http.MethodGet, "", filterID, nil, ff.PopulationTypeSelector())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+
+	Convey("Change population type", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockPc := NewMockPopulationClient(mockCtrl)
+
+		Convey("Given a population type with some of the variables of the filter", func() {
+			expectFilter(mockFc, mockPc, customFilter)
+			expectChange(mockFc, mockPc)
+			mockFc.
+				EXPECT().
+				CreateFlexibleBlueprintCustom(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filter.CreateFlexBlueprintCustomRequest{
+					Dataset: customFilter.Dataset,
+					Dimensions: []filter.ModelDimension{
+						{Name: "ctry", ID: "ctry", Label: "Country", IsAreaType: helpers.ToBoolPtr(true), Options: []string{}},
+						{Name: "sex", ID: "sex", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{"1"}, QualitySummaryURL: "/quality"},
+					},
+					PopulationType: "UR_CE",
+				}).
+				Return("5678", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runPopulationType(http.MethodPost, "", filterID, url.Values{"population_type": {"UR_CE"}}, ff.ChangePopulationType())
+
+			Convey("Then the filter is rebuilt for the population type", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/5678/dimensions")
+			})
+		})
+
+		Convey("Given a population type without some of the areas of the coverage", func() {
+			expectFilter(mockFc, mockPc, customFilter)
+			ctryDim := filter.Dimension{Name: "ctry", ID: "ctry", Label: "Country", IsAreaType: helpers.ToBoolPtr(true)}
+			mockPc.
+				EXPECT().
+				GetAreaTypes(gomock.Any(), gomock.Any()).
+				Return(population.GetAreaTypesResponse{
+					AreaTypes: []population.AreaType{{ID: "ctry", Label: "Country", Hierarchy_Order: 900}},
+				}, nil)
+			mockFc.
+				EXPECT().
+				GetDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).
+				Return(filter.Dimensions{Items: []filter.Dimension{{Name: "ctry"}}}, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimension(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ctry").
+				Return(ctryDim, "", nil)
+			mockFc.
+				EXPECT().
+				GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ctry", gomock.Any()).
+				Return(filter.DimensionOptions{Items: []filter.DimensionOption{{Option: "E92000001"}, {Option: "W92000004"}}}, "", nil)
+			mockPc.
+				EXPECT().
+				GetArea(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(_ interface{}, input population.GetAreaInput) (population.GetAreaResponse, error) {
+					if input.PopulationType == "UR_CE" && input.Area == "W92000004" {
+						return population.GetAreaResponse{}, &testCliError{}
+					}
+					return population.GetAreaResponse{Area: population.Area{ID: input.Area, Label: input.Area}}, nil
+				}).
+				Times(3)
+			mockFc.
+				EXPECT().
+				CreateFlexibleBlueprintCustom(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filter.CreateFlexBlueprintCustomRequest{
+					Dataset: customFilter.Dataset,
+					Dimensions: []filter.ModelDimension{
+						{Name: "ctry", ID: "ctry", Label: "Country", IsAreaType: helpers.ToBoolPtr(true), Options: []string{"E92000001"}},
+					},
+					PopulationType: "UR_CE",
+				}).
+				Return("5678", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runPopulationType(http.MethodPost, "", filterID, url.Values{"population_type": {"UR_CE"}}, ff.ChangePopulationType())
+
+			Convey("Then the filter is rebuilt without the areas which are not available", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/5678/dimensions")
+			})
+		})
+
+		Convey("Given a population type of another type", func() {
+			expectFilter(mockFc, mockPc, customFilter)
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runPopulationType(http.MethodPost, "", filterID, url.Values{"population_type": {"AP"}}, ff.ChangePopulationType())
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+}
+
+func runPopulationType(method, query, filterID string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	encodedFormData := formData.Encode()
+	target := fmt.Sprintf("/filters/%s/population-type", filterID)
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(method, target, strings.NewReader(encodedFormData))
+	if formData != nil {
+		req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+	}
+
+	w := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/population-type", handler)
+	router.ServeHTTP(w, req)
+
+	return w
+}
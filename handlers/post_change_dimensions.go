@@ -14,11 +14,12 @@ import (
 // PostChangeDimensions Handler
 func (f *FilterFlex) PostChangeDimensions() http.HandlerFunc {
 	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
-		postChangeDimensions(w, req, f.FilterClient, accessToken, collectionID)
+		postChangeDimensions(w, req, f, accessToken, collectionID)
 	})
 }
 
-func postChangeDimensions(w http.ResponseWriter, req *http.Request, fc FilterClient, accessToken, collectionID string) {
+func postChangeDimensions(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	fc := f.FilterClient
 	ctx := req.Context()
 	vars := mux.Vars(req)
 	filterID := vars["filterID"]
@@ -50,6 +51,7 @@ func postChangeDimensions(w http.ResponseWriter, req *http.Request, fc FilterCli
 			setStatusCode(req, w, err)
 			return
 		}
+		f.recordFilterHistory(w, req, filterID, true)
 		req.URL.Fragment = "dimensions--added"
 	case Delete:
 		_, err := fc.RemoveDimension(ctx, accessToken, "", collectionID, filterID, form.Value, "")
@@ -61,6 +63,7 @@ func postChangeDimensions(w http.ResponseWriter, req *http.Request, fc FilterCli
 			setStatusCode(req, w, err)
 			return
 		}
+		f.recordFilterHistory(w, req, filterID, true)
 		req.URL.Fragment = "dimensions--added"
 	}
 	req.URL.RawQuery = v.Encode()
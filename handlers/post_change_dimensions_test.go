@@ -35,6 +35,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runPostChangeDimensions(filterID, stubFormData, ff.PostChangeDimensions())
 
@@ -68,6 +69,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runPostChangeDimensions(fid, stubFormData, ff.PostChangeDimensions())
 
@@ -101,6 +103,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runPostChangeDimensions(fid, stubFormData, ff.PostChangeDimensions())
 
@@ -134,6 +137,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runPostChangeDimensions(fid, stubFormData, ff.PostChangeDimensions())
 
@@ -167,6 +171,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runPostChangeDimensions(fid, stubFormData, ff.PostChangeDimensions())
 
@@ -190,6 +195,7 @@ func TestPostChangeDimensionsHandler(t *testing.T) {
 						NewMockDatasetClient(mockCtrl),
 						NewMockPopulationClient(mockCtrl),
 						NewMockZebedeeClient(mockCtrl),
+						testGeography,
 						cfg)
 					w := runPostChangeDimensions("test", stubFormData, ff.PostChangeDimensions())
 
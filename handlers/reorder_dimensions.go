@@ -0,0 +1,135 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// Reorder directions
+const (
+	MoveUp   = "up"
+	MoveDown = "down"
+)
+
+// ReorderDimensions Handler
+func (f *FilterFlex) ReorderDimensions() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		reorderDimensions(w, req, f, accessToken, collectionID)
+	})
+}
+
+// reorderDimensions moves a variable up or down in the filter. The filter API has no way to change the order of
+// the dimensions of a filter, so a new filter is created with the dimensions in the new order in a single request
+func reorderDimensions(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+
+	form, err := parseReorderDimensionsForm(req)
+	if err != nil {
+		log.Error(ctx, "failed to parse reorder dimensions form", err, log.Data{
+			"filter_id": filterID,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+	logData := log.Data{
+		"filter_id": filterID,
+		"dimension": form.Dimension,
+		"direction": form.Direction,
+	}
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	dims, err := f.getModelDimensions(ctx, accessToken, collectionID, filterID)
+	if err != nil {
+		log.Error(ctx, "failed to get dimensions", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// only variables are reordered, the area type keeps its position
+	var positions []int
+	from := -1
+	for i, dim := range dims {
+		if helpers.IsBoolPtr(dim.IsAreaType) {
+			continue
+		}
+		if dim.ID == form.Dimension {
+			from = len(positions)
+		}
+		positions = append(positions, i)
+	}
+	if from < 0 {
+		err := &clientErr{errors.New("dimension not found in filter")}
+		log.Error(ctx, "failed to find dimension to reorder", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	to := from - 1
+	if form.Direction == MoveDown {
+		to = from + 1
+	}
+	if to < 0 || to >= len(positions) {
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions#%s", filterID, form.Dimension), http.StatusMovedPermanently)
+		return
+	}
+	dims[positions[from]], dims[positions[to]] = dims[positions[to]], dims[positions[from]]
+
+	newFilterID, err := f.createFilter(ctx, accessToken, collectionID, filterJob, dims)
+	if err != nil {
+		log.Error(ctx, "failed to create reordered filter", err, logData)
+		setStatusCode(req, w, err)
+		return
+	}
+
+	f.recordFilterHistory(w, req, newFilterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions#%s", newFilterID, form.Dimension), http.StatusMovedPermanently)
+}
+
+// reorderDimensionsForm represents form-data for the ReorderDimensions handler
+type reorderDimensionsForm struct {
+	Dimension string
+	Direction string
+}
+
+// parseReorderDimensionsForm parses form data from a http.Request into a reorderDimensionsForm
+func parseReorderDimensionsForm(req *http.Request) (reorderDimensionsForm, error) {
+	if err := req.ParseForm(); err != nil {
+		return reorderDimensionsForm{}, fmt.Errorf("error parsing form: %w", err)
+	}
+
+	dimension := req.FormValue("dimension")
+	if dimension == "" {
+		return reorderDimensionsForm{}, &clientErr{errors.New("missing required value 'dimension'")}
+	}
+
+	direction := req.FormValue("direction")
+	if direction != MoveUp && direction != MoveDown {
+		return reorderDimensionsForm{}, &clientErr{errors.New("missing or invalid value 'direction', expected 'up' or 'down'")}
+	}
+
+	return reorderDimensionsForm{
+		Dimension: dimension,
+		Direction: direction,
+	}, nil
+}
@@ -0,0 +1,205 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReorderDimensionsHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+	const filterID = "1234"
+
+	filterJob := &filter.GetFilterResponse{
+		FilterID:       filterID,
+		PopulationType: "UR",
+		Dataset: filter.Dataset{
+			DatasetID: "example",
+			Edition:   "2021",
+			Version:   1,
+		},
+	}
+	dims := []filter.Dimension{
+		{Name: "age", ID: "age", IsAreaType: helpers.ToBoolPtr(false)},
+		{Name: "ltla", ID: "ltla", IsAreaType: helpers.ToBoolPtr(true), FilterByParent: "rgn"},
+		{Name: "sex", ID: "sex", IsAreaType: helpers.ToBoolPtr(false)},
+		{Name: "ethnic_group", ID: "ethnic_group", IsAreaType: helpers.ToBoolPtr(false)},
+	}
+	options := map[string][]string{"ltla": {"E12000001"}, "sex": {"1"}}
+
+	expectFilter := func(mockFc *MockFilterClient, job *filter.GetFilterResponse) {
+		mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(job, nil)
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).Return(filter.Dimensions{Items: dims}, "", nil)
+		for _, dim := range dims {
+			opts := filter.DimensionOptions{}
+			for _, opt := range options[dim.Name] {
+				opts.Items = append(opts.Items, filter.DimensionOption{Option: opt})
+			}
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, dim.Name).Return(dim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, dim.Name, gomock.Any()).Return(opts, "", nil)
+		}
+	}
+	modelDims := func(names ...string) []filter.ModelDimension {
+		var modelDims []filter.ModelDimension
+		for _, name := range names {
+			for _, dim := range dims {
+				if dim.Name != name {
+					continue
+				}
+				opts := []string{}
+				opts = append(opts, options[name]...)
+				modelDims = append(modelDims, filter.ModelDimension{
+					Name:           dim.Name,
+					ID:             dim.ID,
+					IsAreaType:     dim.IsAreaType,
+					Options:        opts,
+					FilterByParent: dim.FilterByParent,
+				})
+			}
+		}
+		return modelDims
+	}
+
+	Convey("Reorder dimensions", t, func() {
+		Convey("Given a request to move a variable up", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "ethnic_group")
+			formData.Add("direction", "up")
+
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectFilter(mockFc, filterJob)
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "example", "2021", "1",
+				modelDims("age", "ltla", "ethnic_group", "sex"), "UR").Return("5678", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then a filter is created with the dimensions in the new order", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/5678/dimensions#ethnic_group")
+			})
+
+			Convey("And the new filter is recorded in the history", func() {
+				So(w.Result().Cookies(), ShouldHaveLength, 1)
+			})
+		})
+
+		Convey("Given a request to move a variable down past the area type of a custom filter", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "age")
+			formData.Add("direction", "down")
+
+			customJob := *filterJob
+			customJob.Custom = helpers.ToBoolPtr(true)
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectFilter(mockFc, &customJob)
+			mockFc.EXPECT().CreateFlexibleBlueprintCustom(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filter.CreateFlexBlueprintCustomRequest{
+				Dataset:        filterJob.Dataset,
+				Dimensions:     modelDims("sex", "ltla", "age", "ethnic_group"),
+				PopulationType: "UR",
+			}).Return("5678", "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then the area type keeps its position, options and parent", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/5678/dimensions#age")
+			})
+		})
+
+		Convey("Given a request to move the first variable up", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "age")
+			formData.Add("direction", "up")
+
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectFilter(mockFc, filterJob)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then the filter is not changed", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions#age", filterID))
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+
+		Convey("Given an invalid direction", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "age")
+			formData.Add("direction", "left")
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then the status code should be 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("Given a dimension which is not a variable in the filter", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "ltla")
+			formData.Add("direction", "up")
+
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectFilter(mockFc, filterJob)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then the status code should be 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+
+		Convey("Given the filter API fails to create the reordered filter", func() {
+			formData := url.Values{}
+			formData.Add("dimension", "sex")
+			formData.Add("direction", "down")
+
+			mockFc := NewMockFilterClient(mockCtrl)
+			expectFilter(mockFc, filterJob)
+			mockFc.EXPECT().CreateFlexibleBlueprint(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				Return("", "", errors.New("internal error"))
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runReorderDimensions(filterID, formData, ff.ReorderDimensions())
+
+			Convey("Then the status code should be 500 and the filter is not recorded", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+		})
+	})
+}
+
+func runReorderDimensions(filterID string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	encodedFormData := formData.Encode()
+	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/filters/%s/dimensions/reorder", filterID), strings.NewReader(encodedFormData))
+	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
+
+	w := httptest.NewRecorder()
+
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/dimensions/reorder", handler)
+	router.ServeHTTP(w, req)
+
+	return w
+}
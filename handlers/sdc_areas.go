@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/pagination"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// GetSDCAreas Handler
+func (f *FilterFlex) GetSDCAreas() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		getSDCAreas(w, req, f, lang, accessToken, collectionID)
+	})
+}
+
+// RemoveBlockedAreas Handler
+func (f *FilterFlex) RemoveBlockedAreas() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		removeBlockedAreas(w, req, f, accessToken, collectionID)
+	})
+}
+
+func getSDCAreas(w http.ResponseWriter, req *http.Request, f *FilterFlex, lang, accessToken, collectionID string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+	currentPg, _ := strconv.Atoi(req.URL.Query().Get("page"))
+	if currentPg <= 0 {
+		currentPg = 1
+	}
+
+	var filterJob *filter.GetFilterResponse
+	var filterDims filter.Dimensions
+	var eb zebedee.EmergencyBanner
+	var serviceMsg string
+	var fErr, dErr, zErr error
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		eb, serviceMsg, zErr = getZebContent(ctx, f.ZebedeeClient, accessToken, collectionID, lang)
+	}()
+	go func() {
+		defer wg.Done()
+		filterJob, fErr = f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+			FilterID: filterID,
+			AuthHeaders: filter.AuthHeaders{
+				UserAuthToken: accessToken,
+				CollectionID:  collectionID,
+			},
+		})
+	}()
+	go func() {
+		defer wg.Done()
+		filterDims, _, dErr = f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
+	}()
+	wg.Wait()
+
+	// log zebedee error but don't set a server error
+	if zErr != nil {
+		log.Error(ctx, "unable to get homepage content", zErr, log.Data{"homepage_content": zErr})
+	}
+	if fErr != nil {
+		log.Error(ctx, "failed to get filter", fErr, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, fErr)
+		return
+	}
+	if dErr != nil {
+		log.Error(ctx, "failed to get dimensions", dErr, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, dErr)
+		return
+	}
+
+	areaDim, dimIds, err := getAreaDimension(ctx, f.FilterClient, accessToken, collectionID, filterID, filterDims)
+	if err != nil {
+		log.Error(ctx, "failed to get area type dimension", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	optIDs, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterID, areaDim.Name)
+	if err != nil {
+		log.Error(ctx, "failed to get dimension options", err, log.Data{"dimension_name": areaDim.Name})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	limit := f.DefaultMaximumSearchResults
+	if err = validatePageNo(len(optIDs), limit, currentPg); err != nil {
+		log.Error(ctx, "invalid page number", err, log.Data{"page": currentPg})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	sdc, err := f.getBlockedAreaCount(ctx, accessToken, filterJob.PopulationType, areaDim.ID, areaDim.FilterByParent, copyStrings(dimIds), optIDs)
+	if err != nil {
+		log.Error(ctx, "failed to get blocked area count", err, log.Data{
+			"population_type": filterJob.PopulationType,
+			"variables":       dimIds,
+			"area_codes":      optIDs,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	offset := pagination.GetOffset(limit, currentPg)
+	end := offset + limit
+	if end > len(optIDs) {
+		end = len(optIDs)
+	}
+	pageOpts := optIDs[offset:end]
+
+	results, err := f.getAreasBlockedCount(ctx, accessToken, filterJob.PopulationType, areaDim.ID, areaDim.FilterByParent, dimIds, pageOpts)
+	if err != nil {
+		log.Error(ctx, "failed to get blocked area count for areas", err, log.Data{
+			"population_type": filterJob.PopulationType,
+			"variables":       dimIds,
+			"area_codes":      pageOpts,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	areaType := areaDim.ID
+	if areaDim.FilterByParent != "" {
+		areaType = areaDim.FilterByParent
+	}
+	pageAreas, err := f.getAreasByID(ctx, accessToken, filterJob.PopulationType, areaType, pageOpts)
+	if err != nil {
+		log.Error(ctx, "failed to get areas", err, log.Data{
+			"population": filterJob.PopulationType,
+			"area type":  areaType,
+			"area_codes": pageOpts,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	var areas []model.SDCArea
+	for i, opt := range pageOpts {
+		label := pageAreas[i].Label
+		if pageAreas[i].ID == "" {
+			label = opt
+		}
+		areas = append(areas, model.SDCArea{
+			ID:        opt,
+			Label:     label,
+			IsBlocked: results[i].Blocked > 0,
+			Passed:    results[i].Passed,
+			Blocked:   results[i].Blocked,
+			Total:     results[i].Total,
+		})
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, eb, lang, serviceMsg, filterID)
+	m.SetDatasetContext(f.getDatasetContext(ctx, accessToken, collectionID, filterJob.Dataset, helpers.IsBoolPtr(filterJob.Custom)))
+	sdcAreas := m.CreateSDCAreas(areaDim.Label, *sdc, areas, len(optIDs), currentPg, limit)
+	f.Render.BuildPage(w, sdcAreas, "sdc-areas")
+}
+
+func removeBlockedAreas(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get filter", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	filterDims, _, err := f.FilterClient.GetDimensions(ctx, accessToken, "", collectionID, filterID, &filter.QueryParams{Limit: 500})
+	if err != nil {
+		log.Error(ctx, "failed to get dimensions", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	areaDim, dimIds, err := getAreaDimension(ctx, f.FilterClient, accessToken, collectionID, filterID, filterDims)
+	if err != nil {
+		log.Error(ctx, "failed to get area type dimension", err, log.Data{"filter_id": filterID})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	optIDs, err := getAllDimensionOptionIDs(ctx, f.FilterClient, accessToken, collectionID, filterID, areaDim.Name)
+	if err != nil {
+		log.Error(ctx, "failed to get dimension options", err, log.Data{"dimension_name": areaDim.Name})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// a single query for the whole coverage avoids a query per area when nothing is blocked
+	sdc, err := f.getBlockedAreaCount(ctx, accessToken, filterJob.PopulationType, areaDim.ID, areaDim.FilterByParent, copyStrings(dimIds), optIDs)
+	if err != nil {
+		log.Error(ctx, "failed to get blocked area count", err, log.Data{
+			"population_type": filterJob.PopulationType,
+			"variables":       dimIds,
+			"area_codes":      optIDs,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+	if sdc.Blocked == 0 {
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
+		return
+	}
+
+	results, err := f.getAreasBlockedCount(ctx, accessToken, filterJob.PopulationType, areaDim.ID, areaDim.FilterByParent, dimIds, optIDs)
+	if err != nil {
+		log.Error(ctx, "failed to get blocked area count for areas", err, log.Data{
+			"population_type": filterJob.PopulationType,
+			"variables":       dimIds,
+			"area_codes":      optIDs,
+		})
+		setStatusCode(req, w, err)
+		return
+	}
+
+	// an option is only removed when none of its areas are available
+	var blocked []string
+	for i, opt := range optIDs {
+		if results[i].Blocked > 0 && results[i].Passed == 0 {
+			blocked = append(blocked, opt)
+		}
+	}
+
+	// removing every option would reset the coverage to the default
+	if len(blocked) == len(optIDs) {
+		log.Info(ctx, "all areas are blocked, coverage left unchanged", log.Data{"filter_id": filterID})
+		http.Redirect(w, req, fmt.Sprintf("/filters/%s/sdc/areas", filterID), http.StatusMovedPermanently)
+		return
+	}
+
+	for _, opt := range blocked {
+		_, err := f.FilterClient.RemoveDimensionValue(ctx, accessToken, "", collectionID, filterID, areaDim.Name, opt, "")
+		if err != nil {
+			log.Error(ctx, "failed to remove dimension value", err, log.Data{
+				"dimension": areaDim.Name,
+				"option":    opt,
+			})
+			setStatusCode(req, w, err)
+			return
+		}
+	}
+
+	f.recordFilterHistory(w, req, filterID, true)
+	http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
+}
+
+// getAreaDimension returns the area type dimension of the filter and the ids of all filter dimensions
+func getAreaDimension(ctx context.Context, fc FilterClient, accessToken, collectionID, filterID string, filterDims filter.Dimensions) (filter.Dimension, []string, error) {
+	var areaDim filter.Dimension
+	var dimIds []string
+	for _, dim := range filterDims.Items {
+		dimIds = append(dimIds, dim.ID)
+		if areaDim.Name != "" {
+			continue
+		}
+
+		// Needed to determine whether dimension is_area_type
+		filterDimension, _, err := fc.GetDimension(ctx, accessToken, "", collectionID, filterID, dim.Name)
+		if err != nil {
+			return areaDim, nil, fmt.Errorf("failed to get dimension %s: %w", dim.Name, err)
+		}
+		if isAreaType(filterDimension) {
+			areaDim = filterDimension
+		}
+	}
+
+	return areaDim, dimIds, nil
+}
+
+// getAreasBlockedCount returns the blocked area count for each of the given area options, in the order given.
+// The remaining requests are cancelled when one fails.
+func (f *FilterFlex) getAreasBlockedCount(ctx context.Context, accessToken, populationType, areaTypeID, parent string, dimIds, areaOpts []string) ([]cantabular.GetBlockedAreaCountResult, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	results := make([]cantabular.GetBlockedAreaCountResult, len(areaOpts))
+	var firstErr error
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSDCRequests)
+	for i, opt := range areaOpts {
+		wg.Add(1)
+		go func(i int, opt string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			if ctx.Err() != nil {
+				return
+			}
+
+			sdc, err := f.getBlockedAreaCount(ctx, accessToken, populationType, areaTypeID, parent, copyStrings(dimIds), []string{opt})
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				if firstErr == nil {
+					firstErr = err
+					cancel()
+				}
+				return
+			}
+			results[i] = *sdc
+		}(i, opt)
+	}
+	wg.Wait()
+
+	if firstErr == nil && ctx.Err() != nil {
+		firstErr = ctx.Err()
+	}
+	return results, firstErr
+}
+
+// copyStrings returns a copy of the given slice, as getBlockedAreaCount sorts the variables in place
+func copyStrings(s []string) []string {
+	c := make([]string, len(s))
+	copy(c, s)
+	return c
+}
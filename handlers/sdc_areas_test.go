@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestSDCAreasHandler(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+
+	mockFilterDims := filter.Dimensions{
+		Items: []filter.Dimension{
+			{Name: "ltla", ID: "ltla"},
+			{Name: "sex", ID: "sex"},
+		},
+	}
+	areaDim := filter.Dimension{
+		Name:       "ltla",
+		ID:         "ltla",
+		Label:      "Lower tier local authorities",
+		IsAreaType: helpers.ToBoolPtr(true),
+	}
+	mockOpts := filter.DimensionOptions{
+		Items: []filter.DimensionOption{
+			{Option: "E1"},
+			{Option: "E2"},
+		},
+		TotalCount: 2,
+	}
+	mockBlockedAreaCount := func(_ context.Context, input population.GetBlockedAreaCountInput) (*cantabular.GetBlockedAreaCountResult, error) {
+		switch {
+		case len(input.Filter.Codes) > 1:
+			return &cantabular.GetBlockedAreaCountResult{Passed: 1, Blocked: 1, Total: 2}, nil
+		case input.Filter.Codes[0] == "E1":
+			return &cantabular.GetBlockedAreaCountResult{Passed: 0, Blocked: 1, Total: 1}, nil
+		default:
+			return &cantabular.GetBlockedAreaCountResult{Passed: 1, Blocked: 0, Total: 1}, nil
+		}
+	}
+
+	Convey("Get SDC areas", t, func() {
+		Convey("When the areas are retrieved successfully", func() {
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).DoAndReturn(mockBlockedAreaCount).Times(3)
+			mockPc.EXPECT().GetArea(ctx, gomock.Any()).Return(population.GetAreaResponse{Area: population.Area{Label: "Area"}}, nil).Times(2)
+			mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+			mockRend.EXPECT().BuildPage(gomock.Any(), gomock.Any(), "sdc-areas").Do(func(_ interface{}, p interface{}, _ string) {
+				page := p.(model.SDCAreas)
+				So(page.Areas, ShouldHaveLength, 2)
+				So(page.Areas[0].IsBlocked, ShouldBeTrue)
+				So(page.Areas[1].IsBlocked, ShouldBeFalse)
+				So(page.ShowRemoveButton, ShouldBeTrue)
+			})
+
+			ff := NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, mockZc, testGeography, cfg)
+			w := runSDCAreas("GET", ff.GetSDCAreas())
+
+			Convey("Then the status code is 200", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+			})
+		})
+
+		Convey("When the filter API responds with an error", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(nil, errors.New("sorry"))
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := runSDCAreas("GET", ff.GetSDCAreas())
+
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+
+		Convey("When the page number is out of range", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockZc := NewMockZebedeeClient(mockCtrl)
+			mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/filters/12345/sdc/areas?page=2", nil)
+			router := mux.NewRouter()
+			router.HandleFunc("/filters/{filterID}/sdc/areas", ff.GetSDCAreas())
+			router.ServeHTTP(w, req)
+
+			Convey("Then the status code is 400", func() {
+				So(w.Code, ShouldEqual, http.StatusBadRequest)
+			})
+		})
+	})
+
+	Convey("Remove blocked areas", t, func() {
+		Convey("When some areas are blocked", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).DoAndReturn(mockBlockedAreaCount).Times(3)
+			mockFc.EXPECT().RemoveDimensionValue(ctx, gomock.Any(), gomock.Any(), gomock.Any(), "12345", "ltla", "E1", gomock.Any()).Return("", nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runSDCAreas("POST", ff.RemoveBlockedAreas())
+
+			Convey("Then the blocked area is removed and the user is redirected to the overview", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions")
+			})
+		})
+
+		Convey("When no areas are blocked", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 2, Blocked: 0, Total: 2}, nil)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runSDCAreas("POST", ff.RemoveBlockedAreas())
+
+			Convey("Then the coverage is checked with a single query and the user is redirected to the overview", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/dimensions")
+			})
+		})
+
+		Convey("When all areas are blocked", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 0, Blocked: 1, Total: 1}, nil).Times(3)
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runSDCAreas("POST", ff.RemoveBlockedAreas())
+
+			Convey("Then no areas are removed and the user is redirected to the areas page", func() {
+				So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				So(w.Header().Get("Location"), ShouldEqual, "/filters/12345/sdc/areas")
+			})
+		})
+
+		Convey("When the blocked area count responds with an error", func() {
+			mockFc := NewMockFilterClient(mockCtrl)
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+			mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(mockFilterDims, "", nil)
+			mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla").Return(areaDim, "", nil)
+			mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), "ltla", gomock.Any()).Return(mockOpts, "", nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(nil, errors.New("sorry"))
+
+			ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+			w := runSDCAreas("POST", ff.RemoveBlockedAreas())
+
+			Convey("Then the status code is 500", func() {
+				So(w.Code, ShouldEqual, http.StatusInternalServerError)
+			})
+		})
+	})
+}
+
+func runSDCAreas(method string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/filters/12345/sdc/areas", nil)
+	w := httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/sdc/areas", handler)
+	router.ServeHTTP(w, req)
+	return w
+}
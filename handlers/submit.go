@@ -16,11 +16,12 @@ import (
 // Submit filter outputs handler
 func (f *FilterFlex) Submit() http.HandlerFunc {
 	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
-		submit(w, req, accessToken, collectionID, f.FilterClient)
+		submit(w, req, f, accessToken, collectionID)
 	})
 }
 
-func submit(w http.ResponseWriter, req *http.Request, accessToken, collectionID string, fc FilterClient) {
+func submit(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
+	fc := f.FilterClient
 	vars := mux.Vars(req)
 	filterID := vars["filterID"]
 	ctx := req.Context()
@@ -49,6 +50,7 @@ func submit(w http.ResponseWriter, req *http.Request, accessToken, collectionID
 		setStatusCode(req, w, err)
 		return
 	}
+	f.recordFilterHistory(w, req, filterID, true)
 
 	dataset := filterJob.Dataset
 	dsID := dataset.DatasetID
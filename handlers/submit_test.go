@@ -41,6 +41,7 @@ func TestSubmitHandler(t *testing.T) {
 				NewMockDatasetClient(mockCtrl),
 				NewMockPopulationClient(mockCtrl),
 				NewMockZebedeeClient(mockCtrl),
+				testGeography,
 				cfg)
 			w := testResponse(http.StatusFound, "/filters/12345/submit", ff)
 
@@ -70,6 +71,7 @@ func TestSubmitHandler(t *testing.T) {
 				NewMockDatasetClient(mockCtrl),
 				NewMockPopulationClient(mockCtrl),
 				NewMockZebedeeClient(mockCtrl),
+				testGeography,
 				cfg)
 			w := testResponse(http.StatusFound, "/filters/12345/submit", ff)
 
@@ -88,6 +90,7 @@ func TestSubmitHandler(t *testing.T) {
 				NewMockDatasetClient(mockCtrl),
 				NewMockPopulationClient(mockCtrl),
 				NewMockZebedeeClient(mockCtrl),
+				testGeography,
 				cfg)
 
 			testResponse(http.StatusInternalServerError, "/filters/12345/submit", ff)
@@ -111,6 +114,7 @@ func TestSubmitHandler(t *testing.T) {
 				NewMockDatasetClient(mockCtrl),
 				NewMockPopulationClient(mockCtrl),
 				NewMockZebedeeClient(mockCtrl),
+				testGeography,
 				cfg)
 			testResponse(http.StatusInternalServerError, "/filters/12345/submit", ff)
 		})
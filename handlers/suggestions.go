@@ -0,0 +1,162 @@
+package handlers
+
+import (
+	"context"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// maxConcurrentSDCRequests limits the number of blocked area count requests made at the same time
+const maxConcurrentSDCRequests = 5
+
+// sdcCandidate represents an alternative filter setup to evaluate against disclosure control
+type sdcCandidate struct {
+	suggestion model.SDCSuggestion
+	areaTypeID string
+	parent     string
+	variables  []string
+	areaOpts   []string
+}
+
+// getSDCSuggestions evaluates coarser categorisations of each variable and larger area types against disclosure control.
+// Every candidate is evaluated against the coverage of the current selection. Categorisations are returned when they
+// block fewer areas than the current selection and area types when a larger share of their areas is available.
+// A categorisation is coarser when it has fewer categories than the categories of the variable in the filter.
+// Errors are logged and the affected suggestion skipped, as suggestions are not required to render the page.
+func (f *FilterFlex) getSDCSuggestions(ctx context.Context, accessToken, populationType, areaTypeID, parent string, dimIds, areaOpts []string, fDims []model.FilterDimension, categories map[string][]population.DimensionCategoryItem, categorisations map[string]population.GetCategorisationsResponse, current *cantabular.GetBlockedAreaCountResult) []model.SDCSuggestion {
+	var candidates []sdcCandidate
+	var areaDim model.FilterDimension
+
+	for _, dim := range fDims {
+		if helpers.IsBoolPtr(dim.IsAreaType) {
+			areaDim = dim
+			continue
+		}
+
+		for _, cat := range categorisations[dim.Name].Items {
+			if cat.ID == dim.ID || len(cat.Categories) == 0 || len(cat.Categories) >= len(categories[dim.Name]) {
+				continue
+			}
+			candidates = append(candidates, sdcCandidate{
+				suggestion: model.SDCSuggestion{
+					Dimension:       dim.Name,
+					Value:           cat.ID,
+					Label:           cat.Label,
+					CurrentLabel:    dim.Label,
+					CategoriesCount: len(cat.Categories),
+				},
+				areaTypeID: areaTypeID,
+				parent:     parent,
+				variables:  replaceVariable(dimIds, dim.ID, cat.ID),
+				areaOpts:   areaOpts,
+			})
+		}
+	}
+
+	if areaDim.Name != "" {
+		areaTypes, err := f.PopulationClient.GetAreaTypes(ctx, population.GetAreaTypesInput{
+			AuthTokens: population.AuthTokens{
+				UserAuthToken: accessToken,
+			},
+			PaginationParams: population.PaginationParams{
+				Limit: 1000,
+			},
+			PopulationType: populationType,
+		})
+		if err != nil {
+			log.Error(ctx, "failed to get area types for suggestions", err, log.Data{
+				"population_type": populationType,
+			})
+		}
+
+		var currentOrder int
+		for _, areaType := range areaTypes.AreaTypes {
+			if areaType.ID == areaTypeID {
+				currentOrder = areaType.Hierarchy_Order
+			}
+		}
+		// the coverage is applied to larger area types by filtering them by the areas of the coverage
+		coverageType := parent
+		if coverageType == "" && len(areaOpts) > 0 {
+			coverageType = areaTypeID
+		}
+		for _, areaType := range areaTypes.AreaTypes {
+			if areaType.Hierarchy_Order <= currentOrder {
+				continue
+			}
+			candidates = append(candidates, sdcCandidate{
+				suggestion: model.SDCSuggestion{
+					Dimension:    areaDim.Name,
+					Value:        areaType.ID,
+					Label:        areaType.Label,
+					CurrentLabel: areaDim.Label,
+					IsAreaType:   true,
+				},
+				areaTypeID: areaType.ID,
+				parent:     coverageType,
+				variables:  replaceVariable(dimIds, areaTypeID, areaType.ID),
+				areaOpts:   areaOpts,
+			})
+		}
+	}
+
+	var suggestions []model.SDCSuggestion
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentSDCRequests)
+	for _, c := range candidates {
+		wg.Add(1)
+		go func(c sdcCandidate) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			sdc, err := f.getBlockedAreaCount(ctx, accessToken, populationType, c.areaTypeID, c.parent, c.variables, c.areaOpts)
+			if err != nil {
+				log.Error(ctx, "failed to get blocked area count for suggestion", err, log.Data{
+					"population_type": populationType,
+					"variables":       c.variables,
+					"area_type_id":    c.areaTypeID,
+				})
+				return
+			}
+			if sdc.TableError != "" {
+				return
+			}
+			// a larger area type has a different number of areas, so the share of its areas which pass is compared
+			if c.suggestion.IsAreaType && sdc.Passed*current.Total <= current.Passed*sdc.Total {
+				return
+			}
+			if !c.suggestion.IsAreaType && sdc.Blocked >= current.Blocked {
+				return
+			}
+
+			c.suggestion.Passed = sdc.Passed
+			c.suggestion.Blocked = sdc.Blocked
+			c.suggestion.Total = sdc.Total
+			mu.Lock()
+			suggestions = append(suggestions, c.suggestion)
+			mu.Unlock()
+		}(c)
+	}
+	wg.Wait()
+
+	return suggestions
+}
+
+// replaceVariable returns a copy of the given variables with the old value replaced by the new value
+func replaceVariable(variables []string, old, new string) []string {
+	replaced := make([]string, 0, len(variables))
+	for _, v := range variables {
+		if v == old {
+			v = new
+		}
+		replaced = append(replaced, v)
+	}
+	return replaced
+}
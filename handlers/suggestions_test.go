@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetSDCSuggestions(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	ctx := gomock.Any()
+
+	fDims := []model.FilterDimension{
+		{
+			Dimension: filter.Dimension{
+				Name:       "ltla",
+				ID:         "ltla",
+				Label:      "Lower tier local authorities",
+				IsAreaType: helpers.ToBoolPtr(true),
+			},
+		},
+		{
+			Dimension: filter.Dimension{
+				Name:       "sex",
+				ID:         "sex_3a",
+				Label:      "Sex (3 categories)",
+				IsAreaType: helpers.ToBoolPtr(false),
+			},
+			OptionsCount: 3,
+		},
+	}
+	categories := map[string][]population.DimensionCategoryItem{
+		"sex": make([]population.DimensionCategoryItem, 3),
+	}
+	categorisations := map[string]population.GetCategorisationsResponse{
+		"sex": {
+			Items: []population.Dimension{
+				{ID: "sex_3a", Label: "Sex (3 categories)", Categories: make([]population.Category, 3)},
+				{ID: "sex_2a", Label: "Sex (2 categories)", Categories: make([]population.Category, 2)},
+				{ID: "sex_5a", Label: "Sex (5 categories)", Categories: make([]population.Category, 5)},
+			},
+		},
+	}
+	areaTypes := population.GetAreaTypesResponse{
+		AreaTypes: []population.AreaType{
+			{ID: "ltla", Label: "Lower tier local authorities", Hierarchy_Order: 300},
+			{ID: "rgn", Label: "Regions", Hierarchy_Order: 900},
+			{ID: "msoa", Label: "MSOAs", Hierarchy_Order: 200},
+		},
+	}
+	current := &cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 5, Total: 15}
+
+	Convey("Given alternative categorisations and area types", t, func() {
+		Convey("When the alternatives block fewer areas", func() {
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, input population.GetBlockedAreaCountInput) (*cantabular.GetBlockedAreaCountResult, error) {
+					if helpers.HasStringInSlice("rgn", input.Variables) {
+						return &cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 0, Total: 10}, nil
+					}
+					return &cantabular.GetBlockedAreaCountResult{Passed: 14, Blocked: 1, Total: 15}, nil
+				}).Times(2)
+			ff := NewFilterFlex(nil, nil, nil, mockPc, nil, testGeography, initialiseMockConfig())
+
+			suggestions := ff.getSDCSuggestions(context.Background(), "", "UR", "ltla", "", []string{"ltla", "sex_3a"}, nil, fDims, categories, categorisations, current)
+			Convey("Then only coarser categorisations and larger area types are suggested", func() {
+				So(suggestions, ShouldHaveLength, 2)
+				So(suggestions, ShouldContain, model.SDCSuggestion{
+					Dimension:       "sex",
+					Value:           "sex_2a",
+					Label:           "Sex (2 categories)",
+					CurrentLabel:    "Sex (3 categories)",
+					CategoriesCount: 2,
+					Passed:          14,
+					Blocked:         1,
+					Total:           15,
+				})
+				So(suggestions, ShouldContain, model.SDCSuggestion{
+					Dimension:    "ltla",
+					Value:        "rgn",
+					Label:        "Regions",
+					CurrentLabel: "Lower tier local authorities",
+					IsAreaType:   true,
+					Passed:       10,
+					Blocked:      0,
+					Total:        10,
+				})
+			})
+		})
+
+		Convey("When the filter has a coverage", func() {
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+			var filters []population.Filter
+			var mu sync.Mutex
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).DoAndReturn(
+				func(_ context.Context, input population.GetBlockedAreaCountInput) (*cantabular.GetBlockedAreaCountResult, error) {
+					mu.Lock()
+					defer mu.Unlock()
+					filters = append(filters, input.Filter)
+					return &cantabular.GetBlockedAreaCountResult{Passed: 2, Blocked: 0, Total: 2}, nil
+				}).Times(2)
+			ff := NewFilterFlex(nil, nil, nil, mockPc, nil, testGeography, initialiseMockConfig())
+
+			ff.getSDCSuggestions(context.Background(), "", "UR", "ltla", "", []string{"ltla", "sex_3a"}, []string{"E06000001", "E06000002"}, fDims, categories, categorisations, current)
+			Convey("Then every alternative is evaluated against the coverage", func() {
+				So(filters, ShouldHaveLength, 2)
+				for _, f := range filters {
+					So(f, ShouldResemble, population.Filter{Variable: "ltla", Codes: []string{"E06000001", "E06000002"}})
+				}
+			})
+		})
+
+		Convey("When the filter has a subset of the categories of a variable", func() {
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 0, Total: 10}, nil)
+			ff := NewFilterFlex(nil, nil, nil, mockPc, nil, testGeography, initialiseMockConfig())
+
+			subset := map[string][]population.DimensionCategoryItem{
+				"sex": make([]population.DimensionCategoryItem, 2),
+			}
+			suggestions := ff.getSDCSuggestions(context.Background(), "", "UR", "ltla", "", []string{"ltla", "sex_3a"}, nil, fDims, subset, categorisations, current)
+			Convey("Then categorisations with as many categories as the subset are not suggested", func() {
+				So(suggestions, ShouldHaveLength, 1)
+				So(suggestions[0].Value, ShouldEqual, "rgn")
+			})
+		})
+
+		Convey("When the alternatives do not block fewer areas", func() {
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(areaTypes, nil)
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(&cantabular.GetBlockedAreaCountResult{Passed: 10, Blocked: 5, Total: 15}, nil).Times(2)
+			ff := NewFilterFlex(nil, nil, nil, mockPc, nil, testGeography, initialiseMockConfig())
+
+			suggestions := ff.getSDCSuggestions(context.Background(), "", "UR", "ltla", "", []string{"ltla", "sex_3a"}, nil, fDims, categories, categorisations, current)
+			Convey("Then no suggestions are returned", func() {
+				So(suggestions, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When the population client returns errors", func() {
+			mockPc := NewMockPopulationClient(mockCtrl)
+			mockPc.EXPECT().GetAreaTypes(ctx, gomock.Any()).Return(population.GetAreaTypesResponse{}, errors.New("sorry"))
+			mockPc.EXPECT().GetBlockedAreaCount(ctx, gomock.Any()).Return(nil, errors.New("sorry"))
+			ff := NewFilterFlex(nil, nil, nil, mockPc, nil, testGeography, initialiseMockConfig())
+
+			suggestions := ff.getSDCSuggestions(context.Background(), "", "UR", "ltla", "", []string{"ltla", "sex_3a"}, nil, fDims, categories, categorisations, current)
+			Convey("Then the errors are skipped and no suggestions are returned", func() {
+				So(suggestions, ShouldBeEmpty)
+			})
+		})
+	})
+}
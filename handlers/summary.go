@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mapper"
+	"github.com/ONSdigital/dp-net/v3/handlers"
+	"github.com/ONSdigital/log.go/v2/log"
+	"github.com/gorilla/mux"
+)
+
+// FilterSummary Handler
+func (f *FilterFlex) FilterSummary() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		filterSummary(w, req, f, accessToken, collectionID, lang, false)
+	})
+}
+
+// FilterSummaryCSV Handler
+func (f *FilterFlex) FilterSummaryCSV() http.HandlerFunc {
+	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
+		filterSummary(w, req, f, accessToken, collectionID, lang, true)
+	})
+}
+
+// filterSummary builds the summary of a filter from the data its overview is built from, as a printable page or a csv file
+func filterSummary(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID, lang string, isCSV bool) {
+	ctx := req.Context()
+	vars := mux.Vars(req)
+	filterID := vars["filterID"]
+
+	data, err := f.getFilterData(ctx, accessToken, collectionID, lang, filterID)
+	if err != nil {
+		setStatusCode(req, w, err)
+		return
+	}
+
+	basePage := f.Render.NewBasePageModel()
+	m := mapper.NewMapper(req, basePage, data.eb, lang, data.serviceMsg, filterID)
+	m.SetDatasetContext(data.datasetContext)
+	summary := m.CreateFilterSummary(*data.filterJob, data.dims, data.areas, data.categories, data.pop, *data.sdc, data.isMultivariate)
+
+	if !isCSV {
+		f.Render.BuildPage(w, summary, "filter-summary")
+		return
+	}
+
+	if err := writeSummaryCSV(w, filterID, m.CreateFilterSummaryCSV(summary)); err != nil {
+		log.Error(ctx, "failed to write summary csv", err, log.Data{"filter_id": filterID})
+	}
+}
+
+// writeSummaryCSV writes the rows of a filter summary as a csv file attachment
+func writeSummaryCSV(w http.ResponseWriter, filterID string, rows [][]string) error {
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"filter-%s-summary.csv\"", filterID))
+
+	cw := csv.NewWriter(w)
+	if err := cw.WriteAll(rows); err != nil {
+		return err
+	}
+	return cw.Error()
+}
@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	gomock "github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFilterSummaryHandlers(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	mockCtrl := gomock.NewController(t)
+	cfg := initialiseMockConfig()
+	ctx := gomock.Any()
+	const filterID = "12345"
+
+	filterJob := &filter.GetFilterResponse{
+		FilterID:       filterID,
+		PopulationType: "UR",
+		Dataset:        filter.Dataset{DatasetID: "example", Edition: "2021", Version: 1},
+	}
+	areaDim := filter.Dimension{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true)}
+	sexDim := filter.Dimension{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false)}
+
+	newFilterFlex := func(mockRend *MockRenderClient) *FilterFlex {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).Return(filter.Dimensions{
+			Items: []filter.Dimension{areaDim, sexDim},
+		}, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ltla").Return(areaDim, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "sex").Return(sexDim, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ltla", gomock.Any()).Return(filter.DimensionOptions{
+			Items:      []filter.DimensionOption{{Option: "E06000001"}, {Option: "E06000002"}},
+			TotalCount: 2,
+		}, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "sex", gomock.Any()).Return(filter.DimensionOptions{}, "", nil)
+
+		areaLabels := map[string]string{"E06000001": "Hartlepool", "E06000002": "Middlesbrough"}
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetArea(ctx, gomock.Any()).DoAndReturn(func(_ interface{}, input population.GetAreaInput) (population.GetAreaResponse, error) {
+			return population.GetAreaResponse{Area: population.Area{ID: input.Area, Label: areaLabels[input.Area]}}, nil
+		}).Times(2)
+		mockPc.EXPECT().GetPopulationType(ctx, gomock.Any()).Return(population.GetPopulationTypeResponse{
+			PopulationType: population.PopulationType{Name: "UR", Label: "All usual residents"},
+		}, nil)
+		mockPc.EXPECT().GetDimensionCategories(ctx, gomock.Any()).Return(population.GetDimensionCategoriesResponse{
+			Categories: []population.DimensionCategory{
+				{
+					Id: "sex_2a",
+					Categories: []population.DimensionCategoryItem{
+						{ID: "2", Label: "Male"},
+						{ID: "1", Label: "Female"},
+					},
+				},
+			},
+		}, nil)
+		// the summary does not need the descriptions, categorisations or disclosure control suggestions of the overview
+
+		mockZc := NewMockZebedeeClient(mockCtrl)
+		mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+
+		mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		return NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, mockZc, testGeography, cfg)
+	}
+
+	Convey("Given a filter with selected areas and a variable", t, func() {
+		Convey("When the printable summary is requested", func() {
+			var summary model.FilterSummary
+			mockRend := NewMockRenderClient(mockCtrl)
+			mockRend.
+				EXPECT().
+				BuildPage(gomock.Any(), gomock.Any(), "filter-summary").
+				Do(func(_ interface{}, page interface{}, _ string) {
+					summary = page.(model.FilterSummary)
+				})
+			ff := newFilterFlex(mockRend)
+			w := runSummary("/filters/12345/summary", ff.FilterSummary())
+
+			Convey("Then every area and category is listed", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(summary.DatasetContext.Title, ShouldEqual, "Dataset title")
+				So(summary.PopulationType.Label, ShouldEqual, "All usual residents")
+				So(summary.AreaType.Code, ShouldEqual, "ltla")
+				So(summary.Coverage, ShouldResemble, []model.SummaryItem{
+					{Code: "E06000001", Label: "Hartlepool"},
+					{Code: "E06000002", Label: "Middlesbrough"},
+				})
+				So(summary.Variables, ShouldHaveLength, 1)
+				So(summary.Variables[0].Label, ShouldEqual, "Sex (2 categories)")
+				So(summary.Variables[0].Categories, ShouldResemble, []model.SummaryItem{
+					{Code: "1", Label: "Female"},
+					{Code: "2", Label: "Male"},
+				})
+				So(summary.HasSDC, ShouldBeFalse)
+			})
+		})
+
+		Convey("When the summary csv is requested", func() {
+			ff := newFilterFlex(NewMockRenderClient(mockCtrl))
+			w := runSummary("/filters/12345/summary.csv", ff.FilterSummaryCSV())
+
+			Convey("Then the summary is downloaded as a csv file", func() {
+				So(w.Code, ShouldEqual, http.StatusOK)
+				So(w.Header().Get("Content-Type"), ShouldEqual, "text/csv; charset=utf-8")
+				So(w.Header().Get("Content-Disposition"), ShouldEqual, "attachment; filename=\"filter-12345-summary.csv\"")
+
+				rows, err := csv.NewReader(w.Body).ReadAll()
+				So(err, ShouldBeNil)
+				So(rows[0], ShouldResemble, []string{"Item", "Variable", "Code", "Label"})
+				So(rows, ShouldContain, []string{"Coverage", "ltla", "E06000002", "Middlesbrough"})
+				So(rows, ShouldContain, []string{"Variable", "", "sex_2a", "Sex (2 categories)"})
+				So(rows, ShouldContain, []string{"Category", "sex_2a", "1", "Female"})
+			})
+		})
+	})
+
+	Convey("Given a coverage of more than one page of areas", t, func() {
+		first := filter.DimensionOptions{TotalCount: optionsPageSize + 1}
+		for i := 0; i < optionsPageSize; i++ {
+			first.Items = append(first.Items, filter.DimensionOption{Option: fmt.Sprintf("E%08d", i)})
+		}
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(filterJob, nil)
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).Return(filter.Dimensions{
+			Items: []filter.Dimension{areaDim},
+		}, "", nil)
+		mockFc.EXPECT().GetDimension(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ltla").Return(areaDim, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ltla", &filter.QueryParams{Offset: 0, Limit: optionsPageSize}).Return(first, "", nil)
+		mockFc.EXPECT().GetDimensionOptions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, "ltla", &filter.QueryParams{Offset: optionsPageSize, Limit: optionsPageSize}).Return(filter.DimensionOptions{
+			Items:      []filter.DimensionOption{{Option: "W06000001"}},
+			TotalCount: optionsPageSize + 1,
+		}, "", nil)
+
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().GetArea(ctx, gomock.Any()).DoAndReturn(func(_ interface{}, input population.GetAreaInput) (population.GetAreaResponse, error) {
+			return population.GetAreaResponse{Area: population.Area{ID: input.Area, Label: input.Area}}, nil
+		}).Times(optionsPageSize + 1)
+		mockPc.EXPECT().GetPopulationType(ctx, gomock.Any()).Return(population.GetPopulationTypeResponse{}, nil)
+
+		mockZc := NewMockZebedeeClient(mockCtrl)
+		mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+
+		var summary model.FilterSummary
+		mockRend := NewMockRenderClient(mockCtrl)
+		mockRend.EXPECT().NewBasePageModel().Return(coreModel.NewPage(cfg.PatternLibraryAssetsPath, cfg.SiteDomain))
+		mockRend.
+			EXPECT().
+			BuildPage(gomock.Any(), gomock.Any(), "filter-summary").
+			Do(func(_ interface{}, page interface{}, _ string) {
+				summary = page.(model.FilterSummary)
+			})
+
+		ff := NewFilterFlex(mockRend, mockFc, newDatasetContextClient(mockCtrl), mockPc, mockZc, testGeography, cfg)
+		w := runSummary("/filters/12345/summary", ff.FilterSummary())
+
+		Convey("Then every area of the coverage is listed", func() {
+			So(w.Code, ShouldEqual, http.StatusOK)
+			So(summary.Coverage, ShouldHaveLength, optionsPageSize+1)
+			So(summary.Coverage[optionsPageSize], ShouldResemble, model.SummaryItem{Code: "W06000001", Label: "W06000001"})
+		})
+	})
+
+	Convey("Given the filter cannot be found", t, func() {
+		mockFc := NewMockFilterClient(mockCtrl)
+		mockFc.EXPECT().GetFilter(ctx, gomock.Any()).Return(nil, &testCliError{})
+		mockFc.EXPECT().GetDimensions(ctx, gomock.Any(), gomock.Any(), gomock.Any(), filterID, gomock.Any()).Return(filter.Dimensions{}, "", nil)
+		mockZc := NewMockZebedeeClient(mockCtrl)
+		mockZc.EXPECT().GetHomepageContent(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(zebedee.HomepageContent{}, nil)
+
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), mockFc, NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), mockZc, testGeography, cfg)
+		w := runSummary("/filters/12345/summary.csv", ff.FilterSummaryCSV())
+
+		Convey("Then the status code is 404", func() {
+			So(w.Code, ShouldEqual, http.StatusNotFound)
+		})
+	})
+}
+
+func runSummary(target string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	w := httptest.NewRecorder()
+	router := mux.NewRouter()
+	router.HandleFunc("/filters/{filterID}/summary", handler)
+	router.HandleFunc("/filters/{filterID}/summary.csv", handler)
+	router.ServeHTTP(w, req)
+	return w
+}
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/pagination"
 	"github.com/ONSdigital/dp-net/v3/handlers"
 	"github.com/ONSdigital/log.go/v2/log"
 	"github.com/gorilla/mux"
@@ -17,14 +19,15 @@ import (
 // UpdateCoverage Handler
 func (f *FilterFlex) UpdateCoverage() http.HandlerFunc {
 	return handlers.ControllerHandler(func(w http.ResponseWriter, req *http.Request, lang, collectionID, accessToken string) {
-		updateCoverage(w, req, f.FilterClient, accessToken, collectionID)
+		updateCoverage(w, req, f, accessToken, collectionID)
 	})
 }
 
-func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, accessToken, collectionID string) {
+func updateCoverage(w http.ResponseWriter, req *http.Request, f *FilterFlex, accessToken, collectionID string) {
 	ctx := req.Context()
 	vars := mux.Vars(req)
 	filterID := vars["filterID"]
+	fc := f.FilterClient
 
 	form, err := parseUpdateCoverageForm(req)
 	if isValidationErr(err) {
@@ -43,6 +46,12 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 		return
 	}
 
+	// an error from a previous attempt to add all matching areas is cleared by any further update
+	if q := req.URL.Query(); q.Has("select-all-error") {
+		q.Del("select-all-error")
+		req.URL.RawQuery = q.Encode()
+	}
+
 	switch form.Action {
 	case Continue:
 		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
@@ -56,6 +65,9 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 			v.Set("q", form.Value)
 		}
 		v.Set("c", form.Coverage)
+		if size := req.URL.Query().Get("size"); size != "" {
+			v.Set("size", size)
+		}
 		req.URL.RawQuery = v.Encode()
 	case Delete:
 		_, err := fc.RemoveDimensionValue(ctx, accessToken, "", collectionID, filterID, form.Dimension, form.Value, "")
@@ -67,15 +79,45 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 			setStatusCode(req, w, err)
 			return
 		}
-	case Add:
-		opts, _, err := fc.GetDimensionOptions(ctx, accessToken, "", collectionID, filterID, form.Dimension, &filter.QueryParams{Limit: 500})
+		f.recordFilterHistory(w, req, filterID, true)
+	case Add, AddAll:
+		values := []string{form.Value}
+		if form.Action == AddAll {
+			values, err = f.getSelectAllAreas(ctx, req, accessToken, collectionID, filterID, form)
+			if isValidationErr(err) {
+				log.Info(ctx, "too many matching areas to add at once", log.Data{"filter_id": filterID})
+				q := req.URL.Query()
+				q.Set("select-all-error", "true")
+				req.URL.RawQuery = q.Encode()
+				break
+			}
+			if err != nil {
+				log.Error(ctx, "failed to get areas to add", err, log.Data{
+					"filter_id": filterID,
+					"select":    form.Value,
+				})
+				setStatusCode(req, w, err)
+				return
+			}
+		}
+
+		// every existing option is needed, as the options of the dimension are replaced by the update
+		opts, err := getAllDimensionOptions(ctx, fc, accessToken, collectionID, filterID, form.Dimension)
 		if err != nil {
 			log.Error(ctx, "failed to get dimension options", err, log.Data{"dimension_name": form.Dimension})
 			setStatusCode(req, w, err)
 			return
 		}
 
-		if opts.TotalCount > 0 && form.Coverage != form.OptionType || opts.TotalCount > 0 && form.SetParent != form.LargerArea {
+		// areas added while browsing are either whole branches of a parent area type or individual areas
+		coverage := form.Coverage
+		if coverage == Browse && form.LargerArea != "" {
+			coverage = ParentSearch
+		} else if coverage == Browse {
+			coverage = NameSearch
+		}
+
+		if opts.TotalCount > 0 && coverage != form.OptionType || opts.TotalCount > 0 && form.SetParent != form.LargerArea {
 			log.Info(ctx, "invalid options combination, removing existing options", log.Data{"filter_id": filterID})
 			_, err := fc.DeleteDimensionOptions(ctx, accessToken, "", collectionID, filterID, form.Dimension)
 			if err != nil {
@@ -89,10 +131,17 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 		}
 
 		var options []string
+		added := map[string]bool{}
 		for _, opt := range opts.Items {
 			options = append(options, opt.Option)
+			added[opt.Option] = true
+		}
+		for _, value := range values {
+			if !added[value] {
+				options = append(options, value)
+				added[value] = true
+			}
 		}
-		options = append(options, form.Value)
 
 		dim := filter.Dimension{
 			Name:           form.Dimension,
@@ -110,6 +159,7 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 			setStatusCode(req, w, err)
 			return
 		}
+		f.recordFilterHistory(w, req, filterID, true)
 	case CoverageAll:
 		_, err := fc.DeleteDimensionOptions(ctx, accessToken, "", collectionID, filterID, form.Dimension)
 		if err != nil {
@@ -119,6 +169,7 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 			setStatusCode(req, w, err)
 			return
 		}
+		f.recordFilterHistory(w, req, filterID, true)
 		http.Redirect(w, req, fmt.Sprintf("/filters/%s/dimensions", filterID), http.StatusMovedPermanently)
 		return
 	}
@@ -128,6 +179,8 @@ func updateCoverage(w http.ResponseWriter, req *http.Request, fc FilterClient, a
 		req.URL.Fragment = "search--parent"
 	case NameSearch:
 		req.URL.Fragment = "search--name"
+	case Browse:
+		req.URL.Fragment = "search--browse"
 	}
 
 	http.Redirect(w, req, fmt.Sprint(req.URL), http.StatusMovedPermanently)
@@ -180,6 +233,9 @@ func parseUpdateCoverageForm(req *http.Request) (updateCoverageForm, error) {
 	case ParentSearch:
 		action = Continue
 		value = coverage
+	case Browse:
+		action = Continue
+		value = coverage
 	default:
 		return updateCoverageForm{}, &clientErr{errors.New("unknown coverage type")}
 	}
@@ -216,6 +272,33 @@ func parseUpdateCoverageForm(req *http.Request) (updateCoverageForm, error) {
 		largerArea = parent
 	}
 
+	addBrowseOption := req.FormValue("add-browse-option")
+	if addBrowseOption != "" {
+		action = Add
+		value = addBrowseOption
+		// selecting an area from a parent area type selects all of its areas
+		if level := req.FormValue("browse-level"); level != geogID {
+			largerArea = level
+		}
+	}
+
+	addAll := req.FormValue("add-all")
+	if addAll != "" {
+		if addAll != SelectAllPage && addAll != SelectAllMatching {
+			return updateCoverageForm{}, &clientErr{errors.New("unknown value 'add-all'")}
+		}
+		action = AddAll
+		value = addAll
+		switch coverage {
+		case ParentSearch:
+			largerArea = parent
+		case Browse:
+			if level := req.FormValue("browse-level"); level != geogID {
+				largerArea = level
+			}
+		}
+	}
+
 	deleteOption := req.FormValue("delete-option")
 	if deleteOption != "" {
 		action = Delete
@@ -235,3 +318,61 @@ func parseUpdateCoverageForm(req *http.Request) (updateCoverageForm, error) {
 		OptionType:  optType,
 	}, nil
 }
+
+// getSelectAllAreas returns the areas to add when every result on the current page or every matching result is selected.
+// A validation error is returned if there are more matching areas than can be added at once.
+func (f *FilterFlex) getSelectAllAreas(ctx context.Context, req *http.Request, accessToken, collectionID, filterID string, form updateCoverageForm) ([]string, error) {
+	query := req.URL.Query()
+	var areaTypeID, text string
+	switch form.Coverage {
+	case NameSearch:
+		areaTypeID = form.GeographyID
+		text = query.Get("q")
+	case ParentSearch:
+		areaTypeID = form.LargerArea
+		text = query.Get("pq")
+	case Browse:
+		areaTypeID = form.GeographyID
+		if form.LargerArea != "" {
+			areaTypeID = form.LargerArea
+		}
+	}
+	if areaTypeID == "" || form.Coverage != Browse && text == "" {
+		return nil, &clientErr{errors.New("no search results to add")}
+	}
+
+	filterJob, err := f.FilterClient.GetFilter(ctx, filter.GetFilterInput{
+		FilterID: filterID,
+		AuthHeaders: filter.AuthHeaders{
+			UserAuthToken: accessToken,
+			CollectionID:  collectionID,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pageSize := pagination.GetPageSize(query.Get("size"), f.DefaultMaximumSearchResults)
+	pageNo, _ := strconv.Atoi(query.Get("page"))
+	if pageNo <= 0 {
+		pageNo = 1
+	}
+	if form.Value == SelectAllMatching {
+		pageSize = f.MaxSelectAllAreas
+		pageNo = 1
+	}
+
+	areas, err := getAreas(ctx, pageSize, f.PopulationClient, accessToken, filterJob.PopulationType, areaTypeID, text, pageNo)
+	if err != nil {
+		return nil, err
+	}
+	if form.Value == SelectAllMatching && areas.TotalCount > f.MaxSelectAllAreas {
+		return nil, &validationErr{fmt.Errorf("%d matching areas exceeds the limit of %d", areas.TotalCount, f.MaxSelectAllAreas)}
+	}
+
+	ids := make([]string, 0, len(areas.Areas))
+	for _, area := range areas.Areas {
+		ids = append(ids, area.ID)
+	}
+	return ids, nil
+}
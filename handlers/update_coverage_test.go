@@ -11,6 +11,8 @@ import (
 	"testing"
 
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	gomock "github.com/golang/mock/gomock"
 	"github.com/gorilla/mux"
 	. "github.com/smartystreets/goconvey/convey"
@@ -47,6 +49,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -72,6 +75,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -101,6 +105,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -150,6 +155,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -175,6 +181,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -204,6 +211,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -258,6 +266,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -299,6 +308,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -354,6 +364,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -395,6 +406,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -408,6 +420,272 @@ func TestUpdateCoverageHandler(t *testing.T) {
 			})
 		})
 
+		Convey("Given a valid add browse option request", func() {
+			const filterID = "1234"
+			savedOpts := filter.DimensionOptions{
+				Items:      []filter.DimensionOption{{Option: "E92000001"}},
+				TotalCount: 1,
+			}
+
+			Convey("When an area is added from a parent area type", func() {
+				stubFormData := url.Values{}
+				stubFormData.Add("dimension", "geography")
+				stubFormData.Add("add-browse-option", "W92000004")
+				stubFormData.Add("coverage", "browse")
+				stubFormData.Add("browse-level", "country")
+				stubFormData.Add("option-type", "parent-search")
+				stubFormData.Add("set-parent", "country")
+				stubFormData.Add("geog-id", "city")
+
+				filterClient := NewMockFilterClient(mockCtrl)
+				filterClient.
+					EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(savedOpts, "", nil)
+				filterClient.
+					EXPECT().
+					UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "geography", gomock.Any(), filter.Dimension{
+						Name:           "geography",
+						ID:             "city",
+						IsAreaType:     helpers.ToBoolPtr(true),
+						Options:        []string{"E92000001", "W92000004"},
+						FilterByParent: "country",
+					}).
+					Return(filter.Dimension{}, "", nil)
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					filterClient,
+					NewMockDatasetClient(mockCtrl),
+					NewMockPopulationClient(mockCtrl),
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then the whole branch is added and the location header should match the get coverage screen", func() {
+					So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/geography/coverage#search--browse", filterID))
+				})
+
+				Convey("And the status code should be 301", func() {
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				})
+			})
+
+			Convey("When an individual area is added with saved options from a parent area type", func() {
+				stubFormData := url.Values{}
+				stubFormData.Add("dimension", "geography")
+				stubFormData.Add("add-browse-option", "E06000001")
+				stubFormData.Add("coverage", "browse")
+				stubFormData.Add("browse-level", "city")
+				stubFormData.Add("option-type", "parent-search")
+				stubFormData.Add("set-parent", "country")
+				stubFormData.Add("geog-id", "city")
+
+				filterClient := NewMockFilterClient(mockCtrl)
+				filterClient.
+					EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(savedOpts, "", nil)
+				filterClient.
+					EXPECT().
+					DeleteDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "geography").
+					Return("", nil)
+				filterClient.
+					EXPECT().
+					UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "geography", gomock.Any(), filter.Dimension{
+						Name:       "geography",
+						ID:         "city",
+						IsAreaType: helpers.ToBoolPtr(true),
+						Options:    []string{"E06000001"},
+					}).
+					Return(filter.Dimension{}, "", nil)
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					filterClient,
+					NewMockDatasetClient(mockCtrl),
+					NewMockPopulationClient(mockCtrl),
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then the saved options are replaced and the status code should be 301", func() {
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				})
+			})
+		})
+
+		Convey("Given a valid add all request", func() {
+			const filterID = "1234"
+			savedOpts := filter.DimensionOptions{
+				Items:      []filter.DimensionOption{{Option: "E06000001"}},
+				TotalCount: 1,
+			}
+			found := population.GetAreasResponse{
+				PaginationResponse: population.PaginationResponse{
+					PaginationParams: population.PaginationParams{Limit: 10},
+					Count:            2,
+					TotalCount:       2,
+				},
+				Areas: []population.Area{{ID: "E06000001", Label: "Hartlepool"}, {ID: "E06000002", Label: "Middlesbrough"}},
+			}
+			stubFormData := url.Values{}
+			stubFormData.Add("dimension", "geography")
+			stubFormData.Add("coverage", "name-search")
+			stubFormData.Add("option-type", "name-search")
+			stubFormData.Add("geog-id", "city")
+
+			Convey("When all results on the page are added", func() {
+				stubFormData.Set("add-all", "page")
+				found.TotalCount = 12
+
+				filterClient := NewMockFilterClient(mockCtrl)
+				filterClient.
+					EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+				filterClient.
+					EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(savedOpts, "", nil)
+				filterClient.
+					EXPECT().
+					UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "geography", gomock.Any(), filter.Dimension{
+						Name:       "geography",
+						ID:         "city",
+						IsAreaType: helpers.ToBoolPtr(true),
+						Options:    []string{"E06000001", "E06000002"},
+					}).
+					Return(filter.Dimension{}, "", nil)
+
+				populationClient := NewMockPopulationClient(mockCtrl)
+				populationClient.
+					EXPECT().
+					GetAreas(gomock.Any(), population.GetAreasInput{
+						PaginationParams: population.PaginationParams{Limit: 10, Offset: 10},
+						PopulationType:   "UR",
+						AreaTypeID:       "city",
+						Text:             "hart",
+					}).
+					Return(found, nil)
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					filterClient,
+					NewMockDatasetClient(mockCtrl),
+					populationClient,
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverageWithQuery(filterID, "c=name-search&page=2&q=hart&size=10", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then the areas are added in one update and the user is redirected to the same page of results", func() {
+					So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/geography/coverage?c=name-search&page=2&q=hart&size=10#search--name", filterID))
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				})
+			})
+
+			Convey("When all matching results are added", func() {
+				stubFormData.Set("add-all", "matching")
+
+				filterClient := NewMockFilterClient(mockCtrl)
+				filterClient.
+					EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+				filterClient.
+					EXPECT().
+					GetDimensionOptions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(filter.DimensionOptions{}, "", nil)
+				filterClient.
+					EXPECT().
+					UpdateDimensions(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), filterID, "geography", gomock.Any(), filter.Dimension{
+						Name:       "geography",
+						ID:         "city",
+						IsAreaType: helpers.ToBoolPtr(true),
+						Options:    []string{"E06000001", "E06000002"},
+					}).
+					Return(filter.Dimension{}, "", nil)
+
+				populationClient := NewMockPopulationClient(mockCtrl)
+				populationClient.
+					EXPECT().
+					GetAreas(gomock.Any(), population.GetAreasInput{
+						PaginationParams: population.PaginationParams{Limit: cfg.MaxSelectAllAreas},
+						PopulationType:   "UR",
+						AreaTypeID:       "city",
+						Text:             "hart",
+					}).
+					Return(found, nil)
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					filterClient,
+					NewMockDatasetClient(mockCtrl),
+					populationClient,
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverageWithQuery(filterID, "c=name-search&page=2&q=hart&size=10", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then every matching area is added in one update", func() {
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				})
+			})
+
+			Convey("When more results match than can be added at once", func() {
+				stubFormData.Set("add-all", "matching")
+
+				filterClient := NewMockFilterClient(mockCtrl)
+				filterClient.
+					EXPECT().
+					GetFilter(gomock.Any(), gomock.Any()).
+					Return(&filter.GetFilterResponse{PopulationType: "UR"}, nil)
+
+				found.TotalCount = cfg.MaxSelectAllAreas + 1
+				populationClient := NewMockPopulationClient(mockCtrl)
+				populationClient.
+					EXPECT().
+					GetAreas(gomock.Any(), gomock.Any()).
+					Return(found, nil)
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					filterClient,
+					NewMockDatasetClient(mockCtrl),
+					populationClient,
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverageWithQuery(filterID, "c=name-search&q=hart", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then no areas are added and the user is redirected with an error", func() {
+					So(w.Header().Get("Location"), ShouldEqual, fmt.Sprintf("/filters/%s/dimensions/geography/coverage?c=name-search&q=hart&select-all-error=true#search--name", filterID))
+					So(w.Code, ShouldEqual, http.StatusMovedPermanently)
+				})
+			})
+
+			Convey("When there is no search to add the results of", func() {
+				stubFormData.Set("add-all", "page")
+
+				ff := NewFilterFlex(
+					NewMockRenderClient(mockCtrl),
+					NewMockFilterClient(mockCtrl),
+					NewMockDatasetClient(mockCtrl),
+					NewMockPopulationClient(mockCtrl),
+					NewMockZebedeeClient(mockCtrl),
+					testGeography,
+					cfg)
+				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
+
+				Convey("Then the status code should be 400", func() {
+					So(w.Code, ShouldEqual, http.StatusBadRequest)
+				})
+			})
+		})
+
 		Convey("Given a valid delete option request", func() {
 			stubFormData := url.Values{}
 			stubFormData.Add("dimension", "geography")
@@ -430,6 +708,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -455,6 +734,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -489,6 +769,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -514,6 +795,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage("test", "test", stubFormData, ff.UpdateCoverage())
 
@@ -544,6 +826,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -575,6 +858,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -606,6 +890,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -634,6 +919,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 				w := runUpdateCoverage(filterID, "geography", stubFormData, ff.UpdateCoverage())
 
@@ -661,6 +947,7 @@ func TestUpdateCoverageHandler(t *testing.T) {
 					NewMockDatasetClient(mockCtrl),
 					NewMockPopulationClient(mockCtrl),
 					NewMockZebedeeClient(mockCtrl),
+					testGeography,
 					cfg)
 
 				for name, formData := range tests {
@@ -682,8 +969,16 @@ func TestUpdateCoverageHandler(t *testing.T) {
 }
 
 func runUpdateCoverage(filterID, dimension string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	return runUpdateCoverageWithQuery(filterID, "", formData, handler)
+}
+
+func runUpdateCoverageWithQuery(filterID, query string, formData url.Values, handler http.HandlerFunc) *httptest.ResponseRecorder {
 	encodedFormData := formData.Encode()
-	req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/filters/%s/dimensions/geography/coverage", filterID), strings.NewReader(encodedFormData))
+	target := fmt.Sprintf("/filters/%s/dimensions/geography/coverage", filterID)
+	if query != "" {
+		target += "?" + query
+	}
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(encodedFormData))
 	req.Header.Add("Content-Type", "application/x-www-form-urlencoded")
 	req.Header.Add("Content-Length", strconv.Itoa(len(encodedFormData)))
 
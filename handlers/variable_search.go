@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// maxConcurrentCategorisationRequests limits the number of categorisation requests made at the same time
+const maxConcurrentCategorisationRequests = 5
+
+// getCategoryMatches searches the categories of each variable for the query and returns the label of the first matching category keyed by variable id.
+// The categories of the default categorisation of every variable are searched first, then the categories of the other categorisations of the variables without a match.
+// Errors are logged and the affected variables skipped, as category matches are not required to render the page.
+func (f *FilterFlex) getCategoryMatches(ctx context.Context, accessToken, collectionID, popType, q string, pDims []population.Dimension) map[string]string {
+	matches := map[string]string{}
+	if len(pDims) == 0 || strings.TrimSpace(q) == "" {
+		return matches
+	}
+
+	ids := make([]string, 0, len(pDims))
+	for _, pDim := range pDims {
+		ids = append(ids, pDim.ID)
+	}
+	dimCategories, err := f.PopulationClient.GetDimensionCategories(ctx, population.GetDimensionCategoryInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: popType,
+		Dimensions:     ids,
+	})
+	if err != nil {
+		log.Error(ctx, "failed to get dimension categories for search", err, log.Data{
+			"population_type": popType,
+			"query":           q,
+		})
+	}
+	for _, dimCategory := range dimCategories.Categories {
+		labels := make([]string, 0, len(dimCategory.Categories))
+		for _, cat := range dimCategory.Categories {
+			labels = append(labels, cat.Label)
+		}
+		if label, ok := matchCategory(q, labels); ok {
+			matches[dimCategory.Id] = label
+		}
+	}
+
+	// the variables without a match are collected before searching their categorisations concurrently,
+	// so that the matches are only accessed under the lock while the requests are running
+	unmatched := []string{}
+	for _, id := range ids {
+		if _, ok := matches[id]; !ok {
+			unmatched = append(unmatched, id)
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, maxConcurrentCategorisationRequests)
+	for _, id := range unmatched {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			cats, err := f.getCachedCategorisations(ctx, accessToken, collectionID, popType, id)
+			if err != nil {
+				log.Error(ctx, "failed to get categorisations for search", err, log.Data{
+					"population_type": popType,
+					"dimension_name":  id,
+				})
+				return
+			}
+			for _, cat := range cats.Items {
+				labels := make([]string, 0, len(cat.Categories))
+				for _, c := range cat.Categories {
+					labels = append(labels, c.Label)
+				}
+				if label, ok := matchCategory(q, labels); ok {
+					mu.Lock()
+					matches[id] = label
+					mu.Unlock()
+					return
+				}
+			}
+		}(id)
+	}
+	wg.Wait()
+
+	return matches
+}
+
+// getCachedCategorisations gets the categorisations of a variable, the categorisations of published data are cached
+// as they are searched on every query
+func (f *FilterFlex) getCachedCategorisations(ctx context.Context, accessToken, collectionID, popType, dimension string) (population.GetCategorisationsResponse, error) {
+	key := popType + ":" + dimension
+	cacheable := isCacheable(accessToken, collectionID)
+	if cats, ok := f.Categorisations.Get(key); ok && cacheable {
+		return cats, nil
+	}
+
+	cats, err := f.PopulationClient.GetCategorisations(ctx, population.GetCategorisationsInput{
+		AuthTokens: population.AuthTokens{
+			UserAuthToken: accessToken,
+		},
+		PaginationParams: population.PaginationParams{
+			Limit: 1000,
+		},
+		PopulationType: popType,
+		Dimension:      dimension,
+	})
+	if err != nil {
+		return cats, err
+	}
+	if cacheable {
+		f.Categorisations.Set(key, cats)
+	}
+	return cats, nil
+}
+
+// mergeCategoryMatches adds the variables matched by one of their categories to the variables matched by name
+func mergeCategoryMatches(results population.GetDimensionsResponse, pDims []population.Dimension, matches map[string]string) population.GetDimensionsResponse {
+	found := map[string]bool{}
+	for _, result := range results.Dimensions {
+		found[result.ID] = true
+	}
+	for _, pDim := range pDims {
+		if _, ok := matches[pDim.ID]; ok && !found[pDim.ID] {
+			results.Dimensions = append(results.Dimensions, pDim)
+			found[pDim.ID] = true
+		}
+	}
+	results.Count = len(results.Dimensions)
+	results.TotalCount = len(results.Dimensions)
+	return results
+}
+
+// matchCategory returns the first category label which contains the query, ignoring case and diacritics
+func matchCategory(q string, labels []string) (string, bool) {
+	query := strings.Join(strings.Fields(search.Normalise(q)), " ")
+	for _, label := range labels {
+		if strings.Contains(search.Normalise(label), query) {
+			return label, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	gomock "github.com/golang/mock/gomock"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGetCategoryMatches(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	ctx := context.Background()
+	cfg := initialiseMockConfig()
+	pDims := []population.Dimension{
+		{ID: "economic_activity", Label: "Economic activity status"},
+		{ID: "hh_composition", Label: "Household composition"},
+		{ID: "age", Label: "Age"},
+	}
+
+	Convey("Given the query matches categories of the variables", t, func() {
+		mockPc := NewMockPopulationClient(mockCtrl)
+		mockPc.EXPECT().
+			GetDimensionCategories(ctx, population.GetDimensionCategoryInput{
+				PaginationParams: population.PaginationParams{Limit: 1000},
+				PopulationType:   "UR",
+				Dimensions:       []string{"economic_activity", "hh_composition", "age"},
+			}).
+			Return(population.GetDimensionCategoriesResponse{
+				Categories: []population.DimensionCategory{
+					{
+						Id: "economic_activity",
+						Categories: []population.DimensionCategoryItem{
+							{ID: "1", Label: "Economically active: Employee"},
+							{ID: "2", Label: "Economically inactive: Student"},
+						},
+					},
+					{
+						Id:         "hh_composition",
+						Categories: []population.DimensionCategoryItem{{ID: "1", Label: "One person household"}},
+					},
+					{
+						Id:         "age",
+						Categories: []population.DimensionCategoryItem{{ID: "1", Label: "Aged 15 years and under"}},
+					},
+				},
+			}, nil)
+		mockPc.EXPECT().
+			GetCategorisations(ctx, gomock.Any()).
+			DoAndReturn(func(_ context.Context, input population.GetCategorisationsInput) (population.GetCategorisationsResponse, error) {
+				if input.Dimension == "age" {
+					return population.GetCategorisationsResponse{}, errors.New("internal error")
+				}
+				return population.GetCategorisationsResponse{
+					Items: []population.Dimension{
+						{
+							ID:         "hh_composition_15a",
+							Categories: []population.Category{{ID: "1", Label: "Other household types: All full-time students"}},
+						},
+					},
+				}, nil
+			}).
+			Times(2)
+
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), mockPc, NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+		matches := ff.getCategoryMatches(ctx, "", "", "UR", "student", pDims)
+
+		Convey("Then the first matching category of each variable is returned", func() {
+			So(matches, ShouldResemble, map[string]string{
+				"economic_activity": "Economically inactive: Student",
+				"hh_composition":    "Other household types: All full-time students",
+			})
+		})
+
+		Convey("And the matched variables are added to the variables matched by name", func() {
+			results := mergeCategoryMatches(population.GetDimensionsResponse{
+				Dimensions: []population.Dimension{{ID: "economic_activity", Label: "Economic activity status"}},
+			}, pDims, matches)
+			So(results.Dimensions, ShouldHaveLength, 2)
+			So(results.Dimensions[1].ID, ShouldEqual, "hh_composition")
+			So(results.TotalCount, ShouldEqual, 2)
+		})
+	})
+
+	Convey("Given there is no query", t, func() {
+		ff := NewFilterFlex(NewMockRenderClient(mockCtrl), NewMockFilterClient(mockCtrl), NewMockDatasetClient(mockCtrl), NewMockPopulationClient(mockCtrl), NewMockZebedeeClient(mockCtrl), testGeography, cfg)
+
+		Convey("Then no categories are requested", func() {
+			So(ff.getCategoryMatches(ctx, "", "", "UR", " ", pDims), ShouldBeEmpty)
+		})
+	})
+}
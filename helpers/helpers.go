@@ -86,3 +86,21 @@ func IsBoolPtr(val *bool) bool {
 
 	return *val
 }
+
+// HasSameStrings checks whether the given string arrays contain the same values regardless of order
+func HasSameStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	counts := map[string]int{}
+	for _, s := range a {
+		counts[s]++
+	}
+	for _, s := range b {
+		if counts[s] == 0 {
+			return false
+		}
+		counts[s]--
+	}
+	return true
+}
@@ -179,3 +179,18 @@ func TestIsBoolPtr(t *testing.T) {
 		})
 	})
 }
+
+func TestHasSameStrings(t *testing.T) {
+	Convey("empty arrays return true", t, func() {
+		So(HasSameStrings([]string{}, nil), ShouldBeTrue)
+	})
+	Convey("arrays with the same values in a different order return true", t, func() {
+		So(HasSameStrings([]string{"hello", "world"}, []string{"world", "hello"}), ShouldBeTrue)
+	})
+	Convey("arrays of different lengths return false", t, func() {
+		So(HasSameStrings([]string{"hello"}, []string{"hello", "world"}), ShouldBeFalse)
+	})
+	Convey("arrays with different values return false", t, func() {
+		So(HasSameStrings([]string{"hello", "hello"}, []string{"hello", "world"}), ShouldBeFalse)
+	})
+}
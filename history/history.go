@@ -0,0 +1,131 @@
+package history
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	cookieName = "filter_history"
+	cookiePath = "/filters"
+	cookieAge  = 30 * 24 * time.Hour
+)
+
+// ErrInvalidSignature is returned when the history cookie has been tampered with
+var ErrInvalidSignature = errors.New("invalid filter history signature")
+
+// Entry represents a filter the user has created or edited. LastChanged is when the user last changed the filter
+// through this service, which is zero for a filter they have only viewed.
+type Entry struct {
+	FilterID    string    `json:"id"`
+	LastChanged time.Time `json:"modified"`
+}
+
+// Store is an interface with the methods required to persist a user's filter history
+type Store interface {
+	Get(req *http.Request) ([]Entry, error)
+	Add(w http.ResponseWriter, req *http.Request, filterID string, modified bool) error
+}
+
+// CookieStore persists the filter history in a signed cookie
+type CookieStore struct {
+	secret     []byte
+	maxEntries int
+}
+
+// NewCookieStore creates a new CookieStore signing the history with the given secret
+func NewCookieStore(secret string, maxEntries int) *CookieStore {
+	return &CookieStore{
+		secret:     []byte(secret),
+		maxEntries: maxEntries,
+	}
+}
+
+// Get returns the filter history, most recently modified first followed by the filters which have only been viewed
+func (s *CookieStore) Get(req *http.Request) ([]Entry, error) {
+	c, err := req.Cookie(cookieName)
+	if errors.Is(err, http.ErrNoCookie) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	payload, sig, found := strings.Cut(c.Value, ".")
+	if !found || !hmac.Equal([]byte(sig), []byte(s.sign(payload))) {
+		return nil, ErrInvalidSignature
+	}
+
+	b, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err = json.Unmarshal(b, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Add records the filter in the history. The last modified time of an existing entry is only updated when modified is true.
+// An invalid existing history is replaced.
+func (s *CookieStore) Add(w http.ResponseWriter, req *http.Request, filterID string, modified bool) error {
+	entries, err := s.Get(req)
+	if err != nil {
+		entries = nil
+	}
+
+	added := Entry{FilterID: filterID}
+	if modified {
+		added.LastChanged = time.Now().UTC()
+	}
+	var updated []Entry
+	for _, e := range entries {
+		if e.FilterID != filterID {
+			updated = append(updated, e)
+			continue
+		}
+		if !modified {
+			return nil
+		}
+	}
+	// the added filter is inserted ahead of the entries changed before it so the history stays ordered by last modified,
+	// which places a viewed filter after every modified filter and ahead of the filters viewed before it
+	i := 0
+	for i < len(updated) && updated[i].LastChanged.After(added.LastChanged) {
+		i++
+	}
+	updated = append(updated[:i], append([]Entry{added}, updated[i:]...)...)
+	if s.maxEntries > 0 && len(updated) > s.maxEntries {
+		updated = updated[:s.maxEntries]
+	}
+
+	b, err := json.Marshal(updated)
+	if err != nil {
+		return err
+	}
+	payload := base64.RawURLEncoding.EncodeToString(b)
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     cookieName,
+		Value:    payload + "." + s.sign(payload),
+		Path:     cookiePath,
+		MaxAge:   int(cookieAge.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return nil
+}
+
+func (s *CookieStore) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
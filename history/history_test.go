@@ -0,0 +1,124 @@
+package history
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCookieStore(t *testing.T) {
+	Convey("Given a cookie store", t, func() {
+		store := NewCookieStore("secret", 2)
+
+		Convey("When the request has no history", func() {
+			req := httptest.NewRequest("GET", "/filters/recent", nil)
+			entries, err := store.Get(req)
+
+			Convey("Then no entries are returned", func() {
+				So(err, ShouldBeNil)
+				So(entries, ShouldBeEmpty)
+			})
+		})
+
+		Convey("When a filter is added", func() {
+			w := httptest.NewRecorder()
+			req := httptest.NewRequest("GET", "/filters/1/dimensions", nil)
+			err := store.Add(w, req, "1", false)
+			So(err, ShouldBeNil)
+
+			cookies := w.Result().Cookies()
+			Convey("Then a signed cookie is set", func() {
+				So(cookies, ShouldHaveLength, 1)
+				So(cookies[0].Name, ShouldEqual, cookieName)
+				So(cookies[0].Path, ShouldEqual, cookiePath)
+				So(cookies[0].HttpOnly, ShouldBeTrue)
+			})
+
+			Convey("Then the entry can be read back", func() {
+				entries, err := store.Get(requestWithCookies(cookies))
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 1)
+				So(entries[0].FilterID, ShouldEqual, "1")
+			})
+
+			Convey("Then the history cannot be read with a different secret", func() {
+				_, err := NewCookieStore("other", 2).Get(requestWithCookies(cookies))
+				So(err, ShouldEqual, ErrInvalidSignature)
+			})
+		})
+
+		Convey("When the cookie has been tampered with", func() {
+			req := httptest.NewRequest("GET", "/filters/recent", nil)
+			req.AddCookie(&http.Cookie{Name: cookieName, Value: "W3siaWQiOiIxIn1d.invalid"})
+			_, err := store.Get(req)
+
+			Convey("Then an invalid signature error is returned", func() {
+				So(err, ShouldEqual, ErrInvalidSignature)
+			})
+		})
+
+		Convey("When more filters are added than the maximum", func() {
+			var cookies []*http.Cookie
+			for _, id := range []string{"1", "2", "3"} {
+				w := httptest.NewRecorder()
+				So(store.Add(w, requestWithCookies(cookies), id, true), ShouldBeNil)
+				cookies = w.Result().Cookies()
+			}
+			entries, err := store.Get(requestWithCookies(cookies))
+
+			Convey("Then the oldest entry is dropped and the most recent is first", func() {
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 2)
+				So(entries[0].FilterID, ShouldEqual, "3")
+				So(entries[1].FilterID, ShouldEqual, "2")
+			})
+		})
+
+		Convey("When a filter is viewed after another is modified", func() {
+			w := httptest.NewRecorder()
+			So(store.Add(w, httptest.NewRequest("GET", "/", nil), "1", true), ShouldBeNil)
+			cookies := w.Result().Cookies()
+
+			w = httptest.NewRecorder()
+			So(store.Add(w, requestWithCookies(cookies), "2", false), ShouldBeNil)
+			entries, err := store.Get(requestWithCookies(w.Result().Cookies()))
+
+			Convey("Then the viewed filter is listed after the modified filter", func() {
+				So(err, ShouldBeNil)
+				So(entries, ShouldHaveLength, 2)
+				So(entries[0].FilterID, ShouldEqual, "1")
+				So(entries[1].FilterID, ShouldEqual, "2")
+				So(entries[1].LastChanged.IsZero(), ShouldBeTrue)
+			})
+		})
+
+		Convey("When an existing filter is viewed without being modified", func() {
+			w := httptest.NewRecorder()
+			So(store.Add(w, httptest.NewRequest("GET", "/", nil), "1", false), ShouldBeNil)
+			cookies := w.Result().Cookies()
+
+			w = httptest.NewRecorder()
+			So(store.Add(w, requestWithCookies(cookies), "1", false), ShouldBeNil)
+
+			Convey("Then the cookie is not rewritten", func() {
+				So(w.Result().Cookies(), ShouldBeEmpty)
+			})
+
+			Convey("Then the filter has no last changed time", func() {
+				entries, err := store.Get(requestWithCookies(cookies))
+				So(err, ShouldBeNil)
+				So(entries[0].LastChanged.IsZero(), ShouldBeTrue)
+			})
+		})
+	})
+}
+
+func requestWithCookies(cookies []*http.Cookie) *http.Request {
+	req := httptest.NewRequest("GET", "/filters/recent", nil)
+	for _, c := range cookies {
+		req.AddCookie(c)
+	}
+	return req
+}
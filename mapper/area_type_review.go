@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/welsh"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// CreateAreaTypeReview maps data to the AreaTypeReview model, the kept areas are areas of the parent area type when it is set
+func (m *Mapper) CreateAreaTypeReview(dimensionName string, areaType population.AreaType, parent string, kept, lost []population.Area) model.AreaTypeReview {
+	cfg, _ := config.Get()
+
+	p := model.AreaTypeReview{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, "filter-flex-area-type-review", helper.Localise("AreaTypeReviewTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.Breadcrumb = []coreModel.TaxonomyNode{
+		{
+			Title: helper.Localise("Back", m.lang, 1),
+			URI:   fmt.Sprintf("/filters/%s/dimensions/%s", m.fid, dimensionName),
+		},
+	}
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	p.AreaType = areaType.Label
+	p.Dimension = areaType.ID
+	p.IsParent = parent != ""
+	p.CancelURI = fmt.Sprintf("/filters/%s/dimensions", m.fid)
+
+	p.KeptAreas = []string{}
+	for _, area := range kept {
+		p.KeptAreas = append(p.KeptAreas, welsh.Label(area.ID, area.Label, m.lang))
+	}
+	// the larger areas of lost areas cannot be found, so their codes are listed to help add them again
+	p.LostAreas = []string{}
+	for _, area := range lost {
+		p.LostAreas = append(p.LostAreas, fmt.Sprintf("%s (%s)", welsh.Label(area.ID, area.Label, m.lang), area.ID))
+	}
+
+	return p
+}
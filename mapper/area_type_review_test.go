@@ -0,0 +1,52 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateAreaTypeReview(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	eb := getTestEmergencyBanner()
+	sm := getTestServiceMessage()
+	areaType := population.AreaType{ID: "utla", Label: "Upper tier local authorities"}
+	kept := []population.Area{{ID: "E06000001", Label: "Hartlepool"}}
+	lost := []population.Area{{ID: "E07000008", Label: "Cambridge"}}
+
+	Convey("Given a change of area type", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "en", sm, "12345")
+		p := m.CreateAreaTypeReview("ltla", areaType, "", kept, lost)
+
+		Convey("Then it maps the page metadata", func() {
+			So(p.Metadata.Title, ShouldEqual, "Review your coverage")
+			So(p.Type, ShouldEqual, "filter-flex-area-type-review")
+			So(p.Breadcrumb[0].URI, ShouldEqual, "/filters/12345/dimensions/ltla")
+			So(p.CancelURI, ShouldEqual, "/filters/12345/dimensions")
+		})
+
+		Convey("Then it maps the new area type and the reviewed areas", func() {
+			So(p.Dimension, ShouldEqual, "utla")
+			So(p.AreaType, ShouldEqual, "Upper tier local authorities")
+			So(p.KeptAreas, ShouldResemble, []string{"Hartlepool"})
+			So(p.LostAreas, ShouldResemble, []string{"Cambridge (E07000008)"})
+			So(p.IsParent, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a change of area type keeping parent areas", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "cy", sm, "12345")
+		p := m.CreateAreaTypeReview("ltla", areaType, "ctry", kept, nil)
+
+		Convey("Then the page is localised and the areas are kept as parent areas", func() {
+			So(p.Metadata.Title, ShouldEqual, "Review your coverage (cy)")
+			So(p.IsParent, ShouldBeTrue)
+			So(p.LostAreas, ShouldBeEmpty)
+		})
+	})
+}
@@ -0,0 +1,70 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// CreateCategorySelector maps data to the CategorySelector model, every category is selected when there is no subset
+func (m *Mapper) CreateCategorySelector(dimLabel string, categories population.DimensionCategory, subset []string) model.CategorySelector {
+	cfg, _ := config.Get()
+
+	p := model.CategorySelector{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, "filter-flex-categories", cleanDimensionLabel(dimLabel), m.lang, m.serviceMsg, m.eb)
+	p.Breadcrumb = []coreModel.TaxonomyNode{
+		{
+			Title: helper.Localise("Back", m.lang, 1),
+			URI:   fmt.Sprintf("/filters/%s/dimensions", m.fid),
+		},
+	}
+	p.LeadText = helper.Localise("SelectCategorySubsetLeadText", m.lang, 1)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+
+	selected := make(map[string]bool, len(subset))
+	for _, opt := range subset {
+		selected[opt] = true
+	}
+	cats := make([]population.Category, 0, len(categories.Categories))
+	for _, cat := range categories.Categories {
+		cats = append(cats, population.Category{ID: cat.ID, Label: cat.Label})
+	}
+	p.Categories = []model.SelectableElement{}
+	for _, cat := range sortCategoriesByID(cats) {
+		p.Categories = append(p.Categories, model.SelectableElement{
+			Text:       cat.Label,
+			Value:      cat.ID,
+			Name:       "categories",
+			IsSelected: len(selected) == 0 || selected[cat.ID],
+		})
+	}
+	p.IsSubset = len(selected) > 0
+
+	isValidationError, _ := strconv.ParseBool(m.req.URL.Query().Get("error"))
+	if isValidationError {
+		p.Page.Error = coreModel.Error{
+			Title: p.Page.Metadata.Title,
+			ErrorItems: []coreModel.ErrorItem{
+				{
+					Description: coreModel.Localisation{
+						LocaleKey: "SelectCategorySubsetError",
+						Plural:    1,
+					},
+					URL: "#categories-error",
+				},
+			},
+			Language: m.lang,
+		}
+		p.ErrorId = "categories-error"
+	}
+
+	return p
+}
@@ -0,0 +1,71 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateCategorySelector(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	eb := getTestEmergencyBanner()
+	sm := getTestServiceMessage()
+	categories := population.DimensionCategory{
+		Id: "resident_age_3a",
+		Categories: []population.DimensionCategoryItem{
+			{ID: "3", Label: "Aged 65 years and over"},
+			{ID: "1", Label: "Aged 15 years and under"},
+			{ID: "2", Label: "Aged 16 to 64 years"},
+		},
+	}
+
+	Convey("Given a variable without a category subset", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "en", sm, "12345")
+		p := m.CreateCategorySelector("Age (3 categories)", categories, nil)
+
+		Convey("Then it maps the page metadata", func() {
+			So(p.Metadata.Title, ShouldEqual, "Age")
+			So(p.Type, ShouldEqual, "filter-flex-categories")
+			So(p.LeadText, ShouldEqual, "Select the categories to include")
+			So(p.Breadcrumb[0].URI, ShouldEqual, "/filters/12345/dimensions")
+		})
+
+		Convey("Then every category is selected in category order", func() {
+			So(p.Categories, ShouldResemble, []model.SelectableElement{
+				{Text: "Aged 15 years and under", Value: "1", Name: "categories", IsSelected: true},
+				{Text: "Aged 16 to 64 years", Value: "2", Name: "categories", IsSelected: true},
+				{Text: "Aged 65 years and over", Value: "3", Name: "categories", IsSelected: true},
+			})
+			So(p.IsSubset, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a variable with a category subset", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "en", sm, "12345")
+		p := m.CreateCategorySelector("Age", categories, []string{"2"})
+
+		Convey("Then only the subset is selected", func() {
+			So(p.Categories[0].IsSelected, ShouldBeFalse)
+			So(p.Categories[1].IsSelected, ShouldBeTrue)
+			So(p.Categories[2].IsSelected, ShouldBeFalse)
+			So(p.IsSubset, ShouldBeTrue)
+		})
+	})
+
+	Convey("Given a validation error", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/?error=true", nil), coreModel.Page{}, eb, "en", sm, "12345")
+		p := m.CreateCategorySelector("Age", categories, nil)
+
+		Convey("Then it maps the error", func() {
+			So(p.Error.Title, ShouldEqual, "Age")
+			So(p.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "SelectCategorySubsetError")
+			So(p.ErrorId, ShouldEqual, "categories-error")
+		})
+	})
+}
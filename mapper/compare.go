@@ -0,0 +1,163 @@
+package mapper
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+)
+
+// CreateFilterComparison maps data to the Comparison model
+func (m *Mapper) CreateFilterComparison(a, b model.FilterComparisonItem) model.Comparison {
+	cfg, _ := config.Get()
+
+	p := model.Comparison{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, comparePageType, helper.Localise("CompareTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	p.FilterA = m.mapComparedFilter(a)
+	p.FilterB = m.mapComparedFilter(b)
+
+	p.Rows = append(p.Rows, m.mapComparisonRow(
+		helper.Localise("ComparePopulationType", m.lang, 1),
+		a.Filter.PopulationType,
+		b.Filter.PopulationType,
+		a.Filter.PopulationType != b.Filter.PopulationType,
+	))
+
+	areaA, dimsA := splitComparisonDimensions(a)
+	areaB, dimsB := splitComparisonDimensions(b)
+
+	p.Rows = append(p.Rows, m.mapComparisonRow(
+		helper.Localise("AreaTypeDescription", m.lang, 1),
+		cleanDimensionLabel(areaA.Label),
+		cleanDimensionLabel(areaB.Label),
+		areaA.ID != areaB.ID,
+	))
+	p.Rows = append(p.Rows, m.mapComparisonRow(
+		helper.Localise("AreaTypeCoverageTitle", m.lang, 1),
+		m.mapComparisonCoverage(areaA.Options),
+		m.mapComparisonCoverage(areaB.Options),
+		!helpers.HasSameStrings(areaA.Options, areaB.Options),
+	))
+
+	names := []string{}
+	for name := range dimsA {
+		names = append(names, name)
+	}
+	for name := range dimsB {
+		if _, ok := dimsA[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var variables []model.ComparisonRow
+	for _, name := range names {
+		dimA, inA := dimsA[name]
+		dimB, inB := dimsB[name]
+		row := model.ComparisonRow{
+			ValueA: cleanDimensionLabel(dimA.Label),
+			ValueB: cleanDimensionLabel(dimB.Label),
+		}
+		switch {
+		case !inA:
+			row.Name = row.ValueB
+			row.Status = helper.Localise("CompareStatusAdded", m.lang, 1)
+			row.IsDifferent = true
+		case !inB:
+			row.Name = row.ValueA
+			row.Status = helper.Localise("CompareStatusRemoved", m.lang, 1)
+			row.IsDifferent = true
+		case dimA.ID != dimB.ID:
+			row.Name = cleanDimensionLabel(a.BaseVariables[dimA.ID].Label)
+			if row.Name == "" {
+				row.Name = row.ValueA
+			}
+			row.Status = helper.Localise("CompareStatusCategorisation", m.lang, 1)
+			row.IsDifferent = true
+		default:
+			row = m.mapComparisonRow(row.ValueA, row.ValueA, row.ValueB,
+				!helpers.HasSameStrings(dimA.Options, dimB.Options))
+		}
+		variables = append(variables, row)
+	}
+	sort.SliceStable(variables, func(i, j int) bool {
+		return variables[i].Name < variables[j].Name
+	})
+	p.Rows = append(p.Rows, variables...)
+
+	for _, row := range p.Rows {
+		if row.IsDifferent {
+			p.HasDifferences = true
+			break
+		}
+	}
+
+	return p
+}
+
+// mapComparedFilter maps the summary of a compared filter
+func (m *Mapper) mapComparedFilter(item model.FilterComparisonItem) model.ComparedFilter {
+	cf := model.ComparedFilter{
+		FilterID:     item.Filter.FilterID,
+		DatasetTitle: item.DatasetTitle,
+		URI:          fmt.Sprintf("/filters/%s/dimensions", item.Filter.FilterID),
+		SDC:          helper.Localise("CompareSDCNotApplicable", m.lang, 1),
+	}
+	if item.IsMultivariate {
+		cf.SDC = helper.Localise("CompareSDCResult", m.lang, 1,
+			helper.ThousandsSeparator(item.SDC.Passed),
+			helper.ThousandsSeparator(item.SDC.Total))
+	}
+	return cf
+}
+
+// mapComparisonRow maps a row comparing a value which exists in both filters
+func (m *Mapper) mapComparisonRow(name, valueA, valueB string, isDifferent bool) model.ComparisonRow {
+	status := helper.Localise("CompareStatusSame", m.lang, 1)
+	if isDifferent {
+		status = helper.Localise("CompareStatusChanged", m.lang, 1)
+	}
+	return model.ComparisonRow{
+		Name:        name,
+		ValueA:      valueA,
+		ValueB:      valueB,
+		Status:      status,
+		IsDifferent: isDifferent,
+	}
+}
+
+// mapComparisonCoverage returns the display text for the selected coverage
+func (m *Mapper) mapComparisonCoverage(options []string) string {
+	if len(options) == 0 {
+		return helper.Localise("AreaTypeDefaultCoverage", m.lang, 1)
+	}
+	return strings.Join(options, ", ")
+}
+
+// splitComparisonDimensions returns the area type dimension and the remaining dimensions keyed by base variable,
+// so that different categorisations of the same variable are compared with each other
+func splitComparisonDimensions(item model.FilterComparisonItem) (filter.Dimension, map[string]filter.Dimension) {
+	var area filter.Dimension
+	others := map[string]filter.Dimension{}
+	for _, dim := range item.Dimensions {
+		if helpers.IsBoolPtr(dim.IsAreaType) {
+			area = dim
+			continue
+		}
+		key := dim.Name
+		if variable, ok := item.BaseVariables[dim.ID]; ok && variable.ID != "" {
+			key = variable.ID
+		}
+		others[key] = dim
+	}
+	return area, others
+}
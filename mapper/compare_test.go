@@ -0,0 +1,113 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateFilterComparison(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/filters/compare?a=12345&b=67890", nil)
+	m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "")
+
+	a := model.FilterComparisonItem{
+		Filter:         filter.GetFilterResponse{FilterID: "12345", PopulationType: "UR"},
+		DatasetTitle:   "Dataset A",
+		IsMultivariate: true,
+		SDC:            cantabular.GetBlockedAreaCountResult{Passed: 1000, Total: 2000},
+		Dimensions: []filter.Dimension{
+			{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true), Options: []string{"Hartlepool"}},
+			{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+			{Name: "age", ID: "age_5a", Label: "Age (5 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+		},
+	}
+
+	Convey("Given two identical filters", t, func() {
+		b := a
+		b.Filter.FilterID = "67890"
+		p := m.CreateFilterComparison(a, b)
+
+		Convey("Then the page maps common props", func() {
+			So(p.Type, ShouldEqual, comparePageType)
+			So(p.Metadata.Title, ShouldEqual, "Compare filters")
+		})
+		Convey("Then both filters are summarised", func() {
+			So(p.FilterA.URI, ShouldEqual, "/filters/12345/dimensions")
+			So(p.FilterB.URI, ShouldEqual, "/filters/67890/dimensions")
+			So(p.FilterA.SDC, ShouldEqual, "1,000 out of 2,000 areas available")
+		})
+		Convey("Then there are no differences", func() {
+			So(p.HasDifferences, ShouldBeFalse)
+			So(p.Rows, ShouldHaveLength, 5)
+			for _, row := range p.Rows {
+				So(row.IsDifferent, ShouldBeFalse)
+				So(row.Status, ShouldEqual, "No change")
+			}
+		})
+	})
+
+	Convey("Given two different filters", t, func() {
+		b := model.FilterComparisonItem{
+			Filter:       filter.GetFilterResponse{FilterID: "67890", PopulationType: "UR"},
+			DatasetTitle: "Dataset B",
+			Dimensions: []filter.Dimension{
+				{Name: "rgn", ID: "rgn", Label: "Regions", IsAreaType: helpers.ToBoolPtr(true), Options: []string{}},
+				{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+				{Name: "age_3a", ID: "age_3a", Label: "Age (3 categories)", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+				{Name: "hh_size", ID: "hh_size_4a", Label: "Household size", IsAreaType: helpers.ToBoolPtr(false), Options: []string{}},
+			},
+			BaseVariables: map[string]population.GetBaseVariableResponse{
+				"sex_2a":     {ID: "sex", Label: "Sex"},
+				"age_3a":     {ID: "resident_age", Label: "Age"},
+				"hh_size_4a": {ID: "hh_size", Label: "Household size"},
+			},
+		}
+		a.Dimensions = a.Dimensions[:2]
+		a.Dimensions = append(a.Dimensions, filter.Dimension{Name: "age_5a", ID: "age_5a", Label: "Age (5 categories)", IsAreaType: helpers.ToBoolPtr(false)})
+		a.Dimensions = append(a.Dimensions, filter.Dimension{Name: "eth", ID: "eth_6a", Label: "Ethnic group", IsAreaType: helpers.ToBoolPtr(false)})
+		a.BaseVariables = map[string]population.GetBaseVariableResponse{
+			"sex_2a": {ID: "sex", Label: "Sex"},
+			"age_5a": {ID: "resident_age", Label: "Age"},
+			"eth_6a": {ID: "eth", Label: "Ethnic group"},
+		}
+		p := m.CreateFilterComparison(a, b)
+
+		Convey("Then the differences are mapped", func() {
+			So(p.HasDifferences, ShouldBeTrue)
+			So(p.Rows, ShouldHaveLength, 7)
+			So(p.Rows[0].IsDifferent, ShouldBeFalse)
+			So(p.Rows[1].ValueA, ShouldEqual, "Lower tier local authorities")
+			So(p.Rows[1].ValueB, ShouldEqual, "Regions")
+			So(p.Rows[1].Status, ShouldEqual, "Changed")
+			So(p.Rows[2].ValueA, ShouldEqual, "Hartlepool")
+			So(p.Rows[2].ValueB, ShouldEqual, "England and Wales")
+			So(p.Rows[2].IsDifferent, ShouldBeTrue)
+		})
+		Convey("Then the variables are compared by base variable", func() {
+			So(p.Rows[3].Name, ShouldEqual, "Age")
+			So(p.Rows[3].ValueA, ShouldEqual, "Age")
+			So(p.Rows[3].ValueB, ShouldEqual, "Age")
+			So(p.Rows[3].Status, ShouldEqual, "Different categorisation")
+			So(p.Rows[3].IsDifferent, ShouldBeTrue)
+			So(p.Rows[4].Name, ShouldEqual, "Ethnic group")
+			So(p.Rows[4].Status, ShouldEqual, "Removed")
+			So(p.Rows[5].Name, ShouldEqual, "Household size")
+			So(p.Rows[5].Status, ShouldEqual, "Added")
+			So(p.Rows[6].Name, ShouldEqual, "Sex")
+			So(p.Rows[6].IsDifferent, ShouldBeFalse)
+		})
+		Convey("Then disclosure control is not applicable to the non multivariate filter", func() {
+			So(p.FilterB.SDC, ShouldEqual, "Not applicable")
+		})
+	})
+}
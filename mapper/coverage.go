@@ -4,20 +4,33 @@ import (
 	"fmt"
 	"strconv"
 
-	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/pagination"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/welsh"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 	"github.com/ONSdigital/log.go/v2/log"
 )
 
+// BrowseParent represents a larger area which is browsed into with the number of areas of the selected area type within it
+type BrowseParent struct {
+	Area  population.Area
+	Count int
+}
+
+// SetBrowseParent sets the larger area browsed into on the coverage page
+func (m *Mapper) SetBrowseParent(parent BrowseParent) {
+	m.browseParent = parent
+}
+
 // CreateGetCoverage maps data to the coverage model
-func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setParent, coverage, dim, geogID, releaseDate string, dataset dataset.DatasetDetails, areas population.GetAreasResponse, opts []model.SelectableElement, parents population.GetAreaTypeParentsResponse, hasFilterByParent bool, currentPage int) model.Coverage {
+func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setParent, coverage, dim, geogID, browseLevel string, areas population.GetAreasResponse, opts []model.SelectableElement, parents population.GetAreaTypeParentsResponse, hasFilterByParent bool, currentPage int) model.Coverage {
 	hasValidationErr, _ := strconv.ParseBool(m.req.URL.Query().Get("error"))
+	hasSelectAllErr, _ := strconv.ParseBool(m.req.URL.Query().Get("select-all-error"))
 	cfg, _ := config.Get()
 
 	p := model.Coverage{
@@ -58,10 +71,8 @@ func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setPare
 		Label:    helper.Localise("CoverageSearchLabel", m.lang, 1),
 	}
 
-	p.DatasetId = dataset.ID
-	p.DatasetTitle = dataset.Title
-	p.ReleaseDate = releaseDate
 	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
 
 	if len(parents.AreaTypes) > 1 && parentArea == "" {
 		p.ParentSelect = []model.SelectableElement{
@@ -82,18 +93,40 @@ func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setPare
 		p.ParentSelect = append(p.ParentSelect, sel)
 	}
 
+	// selected areas are displayed with their Welsh names where known
+	opts = append([]model.SelectableElement{}, opts...)
+	for i := range opts {
+		opts[i].Text = welsh.Label(opts[i].Value, opts[i].Text, m.lang)
+	}
+
 	var isParentSearch bool
 	if coverage == parentSearch {
 		isParentSearch = true
 	}
+	addOptionStr := getAddOptionStr(isParentSearch)
+	// selections can only match browsed areas from the same level of the hierarchy
+	isSelectable := true
+	if coverage == browse {
+		addOptionStr = browseOptionName
+		isSelectable = browseLevel == setParent || browseLevel == geogID && setParent == ""
+	}
+	query := nameQ
+	if isParentSearch {
+		query = parentQ
+	}
 	var results []model.SelectableElement
 	for _, area := range areas.Areas {
 		var result model.SelectableElement
-		result.Text = area.Label
+		result.Text = welsh.Label(area.ID, area.Label, m.lang)
 		result.Value = area.ID
-		result.Name = getAddOptionStr(isParentSearch)
+		result.Name = addOptionStr
+		result.Highlight = search.HighlightMatch(result.Text, query)
+		// larger areas can be browsed into to see how many areas they include
+		if coverage == browse && browseLevel != geogID {
+			result.URI = fmt.Sprintf("?c=browse&level=%s&parent=%s#search--browse", browseLevel, area.ID)
+		}
 		for _, opt := range opts {
-			if opt.Value == area.ID {
+			if isSelectable && opt.Value == area.ID {
 				result.IsSelected = true
 				result.Name = "delete-option"
 				break
@@ -103,15 +136,9 @@ func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setPare
 	}
 
 	totalPages := pagination.GetTotalPages(areas.TotalCount, areas.Limit)
-	var paginatedResults coreModel.Pagination
+	var paginatedResults model.Pagination
 	if totalPages > 1 {
-		paginatedResults = coreModel.Pagination{
-			CurrentPage:       currentPage,
-			TotalPages:        totalPages,
-			Limit:             areas.Limit,
-			FirstAndLastPages: pagination.GetFirstAndLastPages(m.req, totalPages),
-			PagesToDisplay:    pagination.GetPagesToDisplay(currentPage, totalPages, m.req),
-		}
+		paginatedResults = pagination.GetPagination(m.req, currentPage, totalPages, areas.Limit, m.lang)
 	}
 
 	if len(opts) > 0 && hasFilterByParent {
@@ -132,12 +159,25 @@ func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setPare
 		p.NameSearchOutput.Results = results
 		p.NameSearchOutput.HasNoResults = len(p.NameSearchOutput.Results) == 0
 		p.NameSearchOutput.Pagination = paginatedResults
+		m.mapResultsActions(&p.NameSearchOutput, areas, cfg.MaxSelectAllAreas)
 	case parentSearch:
 		p.CoverageType = parentSearch
 		p.ParentSearchOutput.Results = results
 		p.ParentSearchOutput.HasNoResults = len(p.ParentSearchOutput.Results) == 0 && !hasValidationErr
 		p.ParentSearchOutput.Pagination = paginatedResults
+		m.mapResultsActions(&p.ParentSearchOutput, areas, cfg.MaxSelectAllAreas)
+	case browse:
+		p.CoverageType = browse
+		p.BrowseOutput.Results = results
+		p.BrowseOutput.HasNoResults = len(p.BrowseOutput.Results) == 0 && m.browseParent.Area.ID == ""
+		p.BrowseOutput.Pagination = paginatedResults
+		m.mapResultsActions(&p.BrowseOutput, areas, cfg.MaxSelectAllAreas)
+		if len(opts) > 0 {
+			p.BrowseOutput.Selections = opts
+			p.BrowseOutput.SelectionsTitle = helper.Localise("AreasAddedTitle", m.lang, len(opts))
+		}
 	}
+	p.BrowseOutput.Language = m.lang
 
 	if hasValidationErr {
 		p.Page.Error = coreModel.Error{
@@ -153,9 +193,94 @@ func (m *Mapper) CreateGetCoverage(geogName, nameQ, parentQ, parentArea, setPare
 			},
 			Language: m.lang,
 		}
+	} else if hasSelectAllErr {
+		p.Page.Error = coreModel.Error{
+			Title: p.Metadata.Title,
+			ErrorItems: []coreModel.ErrorItem{
+				{
+					Description: coreModel.Localisation{
+						Text: helper.Localise("CoverageSelectAllLimitError", m.lang, 1, strconv.Itoa(cfg.MaxSelectAllAreas)),
+					},
+					URL: "#coverage-error",
+				},
+			},
+			Language: m.lang,
+		}
 	}
 
 	p.IsSelectParents = len(parents.AreaTypes) > 0
 
+	// browse levels run from the largest parent area type down to the selected area type
+	if p.IsSelectParents {
+		for _, parent := range sortAreaTypes(parents.AreaTypes) {
+			p.BrowseLevels = append(p.BrowseLevels, model.SelectableElement{
+				Text:       parent.Label,
+				Value:      parent.ID,
+				IsSelected: parent.ID == browseLevel,
+			})
+		}
+		p.BrowseLevels = append(p.BrowseLevels, model.SelectableElement{
+			Text:       geography,
+			Value:      geogID,
+			IsSelected: geogID == browseLevel,
+		})
+	}
+	p.BrowseLevel = browseLevel
+
+	// the areas within a larger area cannot be listed, so the larger area is added as a whole branch
+	if coverage == browse && m.browseParent.Area.ID != "" {
+		parent := model.SelectableElement{
+			Text:      welsh.Label(m.browseParent.Area.ID, m.browseParent.Area.Label, m.lang),
+			Value:     m.browseParent.Area.ID,
+			Name:      browseOptionName,
+			InnerText: helper.Localise("CoverageBrowseParentCount", m.lang, m.browseParent.Count, helper.ThousandsSeparator(m.browseParent.Count)),
+		}
+		for _, opt := range opts {
+			if isSelectable && opt.Value == parent.Value {
+				parent.IsSelected = true
+				parent.Name = "delete-option"
+				break
+			}
+		}
+		p.BrowseParent = parent
+
+		for _, level := range p.BrowseLevels {
+			if level.Value == browseLevel {
+				p.BrowseBreadcrumbs = append(p.BrowseBreadcrumbs, coreModel.TaxonomyNode{
+					Title: level.Text,
+					URI:   fmt.Sprintf("?c=browse&level=%s#search--browse", browseLevel),
+				})
+			}
+		}
+		p.BrowseBreadcrumbs = append(p.BrowseBreadcrumbs, coreModel.TaxonomyNode{
+			Title: parent.Text,
+		})
+	}
+
 	return p
 }
+
+// mapResultsActions maps the page sizes and the action to add every matching area to the search output
+func (m *Mapper) mapResultsActions(output *model.SearchOutput, areas population.GetAreasResponse, maxSelectAll int) {
+	output.TotalCount = areas.TotalCount
+	if areas.TotalCount > pagination.PageSizes[0] {
+		for _, size := range pagination.PageSizes {
+			output.PageSizes = append(output.PageSizes, model.PageSize{
+				Size:      size,
+				URL:       pagination.GetPageSizeUrl(m.req, size),
+				IsCurrent: size == areas.Limit,
+			})
+		}
+	}
+
+	// adding every matching area is only offered when they do not all fit on the page
+	if areas.TotalCount <= len(areas.Areas) {
+		return
+	}
+	if areas.TotalCount > maxSelectAll {
+		output.SelectAllLimitHint = helper.Localise("CoverageSelectAllLimit", m.lang, 1, strconv.Itoa(maxSelectAll))
+		return
+	}
+	output.CanSelectAllMatching = true
+	output.SelectAllMatchingLabel = helper.Localise("CoverageSelectAllMatching", m.lang, 1, helper.ThousandsSeparator(areas.TotalCount))
+}
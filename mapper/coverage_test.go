@@ -4,8 +4,9 @@ import (
 	"net/http/httptest"
 	"testing"
 
-	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
@@ -21,6 +22,11 @@ func TestGetCoverage(t *testing.T) {
 		eb := getTestEmergencyBanner()
 		sm := getTestServiceMessage()
 		m := NewMapper(req, coreModel.Page{}, eb, lang, sm, "12345")
+		m.SetDatasetContext(DatasetContext{
+			Dataset:     filter.Dataset{DatasetID: "dataset-id", Edition: "2021", Version: 1},
+			Title:       "Dataset title",
+			ReleaseDate: "2022/11/29",
+		})
 
 		Convey("When the parameters are valid", func() {
 			coverage := m.CreateGetCoverage(
@@ -32,8 +38,7 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"dim",
 				"geogID",
-				"2022/11/29",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
+				"",
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -105,7 +110,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				parents,
@@ -133,7 +137,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -163,7 +166,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				parents,
@@ -200,7 +202,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				parents,
@@ -237,7 +238,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -268,7 +268,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -294,6 +293,38 @@ func TestGetCoverage(t *testing.T) {
 			})
 		})
 
+		Convey("When a name search matches part of an area label", func() {
+			mockedSearchResults := population.GetAreasResponse{
+				Areas: []population.Area{
+					{
+						Label: "Ynys Môn",
+						ID:    "W06000001",
+					},
+				},
+			}
+
+			coverage := m.CreateGetCoverage(
+				"Unknown geography",
+				"mon",
+				"",
+				"",
+				"",
+				"name-search",
+				"",
+				"",
+				"",
+				mockedSearchResults,
+				[]model.SelectableElement{},
+				population.GetAreaTypeParentsResponse{},
+				false,
+				1)
+
+			Convey("Then the matched text is highlighted", func() {
+				So(coverage.NameSearchOutput.Results[0].Highlight.Before, ShouldEqual, "Ynys ")
+				So(coverage.NameSearchOutput.Results[0].Highlight.Match, ShouldEqual, "Môn")
+			})
+		})
+
 		Convey("When a valid name search is performed with paginated results", func() {
 			mockedSearchResults := population.GetAreasResponse{
 				Areas: []population.Area{
@@ -322,7 +353,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -333,34 +363,44 @@ func TestGetCoverage(t *testing.T) {
 			})
 
 			Convey("Then it paginates the search results", func() {
-				expectedPagination := coreModel.Pagination{
+				expectedPagination := model.Pagination{
 					CurrentPage: 2,
-					PagesToDisplay: []coreModel.PageToDisplay{
+					TotalPages:  3,
+					Limit:       50,
+					Summary:     "Page 2 of 3",
+					Label:       "Pagination (Page 2 of 3)",
+					Previous: model.PaginationLink{
+						Text:  "Previous",
+						Label: "Go to the previous page (Page 1)",
+						URL:   "/?page=1",
+					},
+					Next: model.PaginationLink{
+						Text:  "Next",
+						Label: "Go to the next page (Page 3)",
+						URL:   "/?page=3",
+					},
+					Items: []model.PaginationItem{
 						{
 							PageNumber: 1,
+							Label:      "Page 1 of 3",
 							URL:        "/?page=1",
 						},
 						{
 							PageNumber: 2,
+							Label:      "Current page (Page 2 of 3)",
 							URL:        "/?page=2",
+							IsCurrent:  true,
 						},
 						{
 							PageNumber: 3,
+							Label:      "Page 3 of 3",
 							URL:        "/?page=3",
 						},
 					},
-					FirstAndLastPages: []coreModel.PageToDisplay{
-						{
-							PageNumber: 1,
-							URL:        "/?page=1",
-						},
-						{
-							PageNumber: 3,
-							URL:        "/?page=3",
-						},
+					RelLinks: []model.RelLink{
+						{Rel: "prev", URL: "/?page=1"},
+						{Rel: "next", URL: "/?page=3"},
 					},
-					TotalPages: 3,
-					Limit:      50,
 				}
 				So(coverage.NameSearchOutput.Pagination, ShouldResemble, expectedPagination)
 			})
@@ -386,7 +426,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -427,7 +466,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -471,7 +509,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -505,7 +542,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -542,7 +578,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -570,7 +605,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				population.GetAreasResponse{},
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -606,7 +640,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -647,7 +680,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -691,7 +723,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -753,7 +784,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				mockedOpt,
 				population.GetAreaTypeParentsResponse{},
@@ -813,7 +843,6 @@ func TestGetCoverage(t *testing.T) {
 				"",
 				"",
 				"",
-				dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"},
 				mockedSearchResults,
 				[]model.SelectableElement{},
 				population.GetAreaTypeParentsResponse{},
@@ -825,37 +854,230 @@ func TestGetCoverage(t *testing.T) {
 			})
 
 			Convey("Then it paginates the search results", func() {
-				expectedPagination := coreModel.Pagination{
+				expectedPagination := model.Pagination{
 					CurrentPage: 2,
-					PagesToDisplay: []coreModel.PageToDisplay{
+					TotalPages:  3,
+					Limit:       50,
+					Summary:     "Page 2 of 3",
+					Label:       "Pagination (Page 2 of 3)",
+					Previous: model.PaginationLink{
+						Text:  "Previous",
+						Label: "Go to the previous page (Page 1)",
+						URL:   "/?page=1",
+					},
+					Next: model.PaginationLink{
+						Text:  "Next",
+						Label: "Go to the next page (Page 3)",
+						URL:   "/?page=3",
+					},
+					Items: []model.PaginationItem{
 						{
 							PageNumber: 1,
+							Label:      "Page 1 of 3",
 							URL:        "/?page=1",
 						},
 						{
 							PageNumber: 2,
+							Label:      "Current page (Page 2 of 3)",
 							URL:        "/?page=2",
+							IsCurrent:  true,
 						},
 						{
 							PageNumber: 3,
+							Label:      "Page 3 of 3",
 							URL:        "/?page=3",
 						},
 					},
-					FirstAndLastPages: []coreModel.PageToDisplay{
-						{
-							PageNumber: 1,
-							URL:        "/?page=1",
-						},
-						{
-							PageNumber: 3,
-							URL:        "/?page=3",
-						},
+					RelLinks: []model.RelLink{
+						{Rel: "prev", URL: "/?page=1"},
+						{Rel: "next", URL: "/?page=3"},
 					},
-					TotalPages: 3,
-					Limit:      50,
 				}
 				So(coverage.ParentSearchOutput.Pagination, ShouldResemble, expectedPagination)
 			})
 		})
 	})
 }
+
+func TestGetCoverageBrowse(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/filters/12345/dimensions/geography/coverage?c=browse", nil)
+	m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+	parents := population.GetAreaTypeParentsResponse{
+		AreaTypes: []population.AreaType{
+			{ID: "rgn", Label: "Regions", Hierarchy_Order: 800},
+			{ID: "ctry", Label: "Countries", Hierarchy_Order: 900},
+		},
+	}
+	areas := population.GetAreasResponse{
+		Areas: []population.Area{
+			{ID: "E92000001", Label: "England"},
+			{ID: "W92000004", Label: "Wales"},
+		},
+	}
+	opts := []model.SelectableElement{{Text: "England", Value: "E92000001"}}
+
+	Convey("Given the user is browsing a parent area type", t, func() {
+		coverage := m.CreateGetCoverage("Country", "", "", "", "ctry", "browse", "dim", "geogID", "ctry", areas, opts, parents, true, 1)
+
+		Convey("Then the levels are ordered from the largest area type", func() {
+			So(coverage.BrowseLevels, ShouldHaveLength, 3)
+			So(coverage.BrowseLevels[0].Value, ShouldEqual, "ctry")
+			So(coverage.BrowseLevels[0].IsSelected, ShouldBeTrue)
+			So(coverage.BrowseLevels[1].Value, ShouldEqual, "rgn")
+			So(coverage.BrowseLevels[2].Value, ShouldEqual, "geogID")
+			So(coverage.BrowseLevel, ShouldEqual, "ctry")
+		})
+		Convey("Then the areas are mapped as browse results", func() {
+			So(coverage.CoverageType, ShouldEqual, "browse")
+			So(coverage.BrowseOutput.Results, ShouldHaveLength, 2)
+			So(coverage.BrowseOutput.Results[0].IsSelected, ShouldBeTrue)
+			So(coverage.BrowseOutput.Results[0].Name, ShouldEqual, "delete-option")
+			So(coverage.BrowseOutput.Results[1].Name, ShouldEqual, "add-browse-option")
+			So(coverage.BrowseOutput.Selections, ShouldResemble, opts)
+		})
+		Convey("Then the areas can be browsed into", func() {
+			So(coverage.BrowseOutput.Results[1].URI, ShouldEqual, "?c=browse&level=ctry&parent=W92000004#search--browse")
+			So(coverage.BrowseParent.Value, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the user is browsing the selected area type", t, func() {
+		coverage := m.CreateGetCoverage("Country", "", "", "", "", "browse", "dim", "geogID", "geogID", areas, nil, parents, false, 1)
+
+		Convey("Then the areas cannot be browsed into", func() {
+			So(coverage.BrowseOutput.Results[0].URI, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the user has browsed into a larger area", t, func() {
+		drillReq := httptest.NewRequest("", "/filters/12345/dimensions/geography/coverage?c=browse&level=ctry&parent=E92000001", nil)
+		dm := NewMapper(drillReq, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		dm.SetBrowseParent(BrowseParent{
+			Area:  population.Area{ID: "E92000001", Label: "England"},
+			Count: 1200,
+		})
+		coverage := dm.CreateGetCoverage("Country", "", "", "", "ctry", "browse", "dim", "geogID", "ctry", population.GetAreasResponse{}, opts, parents, true, 1)
+
+		Convey("Then the larger area is mapped with the number of areas within it", func() {
+			So(coverage.BrowseParent.Text, ShouldEqual, "England")
+			So(coverage.BrowseParent.Value, ShouldEqual, "E92000001")
+			So(coverage.BrowseParent.InnerText, ShouldEqual, "Includes 1,200 areas")
+			So(coverage.BrowseOutput.HasNoResults, ShouldBeFalse)
+		})
+		Convey("Then the larger area is selected when it has been added", func() {
+			So(coverage.BrowseParent.IsSelected, ShouldBeTrue)
+			So(coverage.BrowseParent.Name, ShouldEqual, "delete-option")
+		})
+		Convey("Then the breadcrumbs lead back up to the level browsed", func() {
+			So(coverage.BrowseBreadcrumbs, ShouldHaveLength, 2)
+			So(coverage.BrowseBreadcrumbs[0].Title, ShouldEqual, "Countries")
+			So(coverage.BrowseBreadcrumbs[0].URI, ShouldEqual, "?c=browse&level=ctry#search--browse")
+			So(coverage.BrowseBreadcrumbs[1].Title, ShouldEqual, "England")
+			So(coverage.BrowseBreadcrumbs[1].URI, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the user is browsing a different level to the saved options", t, func() {
+		coverage := m.CreateGetCoverage("Country", "", "", "", "ctry", "browse", "dim", "geogID", "rgn", areas, opts, parents, true, 1)
+
+		Convey("Then the results are not selected", func() {
+			So(coverage.BrowseOutput.Results[0].IsSelected, ShouldBeFalse)
+			So(coverage.BrowseOutput.Results[0].Name, ShouldEqual, "add-browse-option")
+		})
+	})
+
+	Convey("Given there are no parent area types", t, func() {
+		coverage := m.CreateGetCoverage("Country", "", "", "", "", "", "dim", "geogID", "", population.GetAreasResponse{}, nil, population.GetAreaTypeParentsResponse{}, false, 1)
+
+		Convey("Then there are no levels to browse", func() {
+			So(coverage.BrowseLevels, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestGetCoverageWelshAreaNames(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/filters/12345/dimensions/geography/coverage?c=name-search&q=caerdydd", nil)
+	areas := population.GetAreasResponse{
+		Areas: []population.Area{{ID: "W06000015", Label: "Cardiff"}},
+	}
+	opts := []model.SelectableElement{{Text: "Isle of Anglesey", Value: "W06000001"}}
+
+	Convey("Given the language is Welsh", t, func() {
+		m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "cy", getTestServiceMessage(), "12345")
+		coverage := m.CreateGetCoverage("Country", "caerdydd", "", "", "", "name-search", "dim", "geogID", "", areas, opts, population.GetAreaTypeParentsResponse{}, false, 1)
+
+		Convey("Then the results are displayed with their Welsh names", func() {
+			So(coverage.NameSearchOutput.Results[0].Text, ShouldEqual, "Caerdydd")
+			So(coverage.NameSearchOutput.Results[0].Highlight.Match, ShouldEqual, "Caerdydd")
+		})
+		Convey("Then the selections are displayed with their Welsh names", func() {
+			So(coverage.NameSearchOutput.Selections[0].Text, ShouldEqual, "Ynys Môn")
+			So(opts[0].Text, ShouldEqual, "Isle of Anglesey")
+		})
+	})
+
+	Convey("Given the language is English", t, func() {
+		m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		coverage := m.CreateGetCoverage("Country", "caerdydd", "", "", "", "name-search", "dim", "geogID", "", areas, opts, population.GetAreaTypeParentsResponse{}, false, 1)
+
+		Convey("Then the results are displayed with their English names", func() {
+			So(coverage.NameSearchOutput.Results[0].Text, ShouldEqual, "Cardiff")
+			So(coverage.NameSearchOutput.Selections[0].Text, ShouldEqual, "Isle of Anglesey")
+		})
+	})
+}
+
+func TestGetCoverageResultsActions(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	cfg, _ := config.Get()
+	req := httptest.NewRequest("", "/filters/12345/dimensions/geography/coverage?c=name-search&q=hart&page=2&size=10", nil)
+	m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+	areas := population.GetAreasResponse{
+		PaginationResponse: population.PaginationResponse{
+			PaginationParams: population.PaginationParams{Limit: 10, Offset: 10},
+			Count:            1,
+			TotalCount:       11,
+		},
+		Areas: []population.Area{{ID: "E06000001", Label: "Hartlepool"}},
+	}
+
+	Convey("Given the search results span more than one page", t, func() {
+		coverage := m.CreateGetCoverage("City", "hart", "", "", "", "name-search", "dim", "city", "", areas, []model.SelectableElement{}, population.GetAreaTypeParentsResponse{}, false, 2)
+
+		Convey("Then the page sizes link to the first page of results with that size", func() {
+			So(coverage.NameSearchOutput.PageSizes, ShouldHaveLength, 4)
+			So(coverage.NameSearchOutput.PageSizes[0].IsCurrent, ShouldBeTrue)
+			So(coverage.NameSearchOutput.PageSizes[1].URL, ShouldEqual, "/filters/12345/dimensions/geography/coverage?c=name-search&q=hart&size=20")
+		})
+		Convey("Then every matching result can be added", func() {
+			So(coverage.NameSearchOutput.TotalCount, ShouldEqual, 11)
+			So(coverage.NameSearchOutput.CanSelectAllMatching, ShouldBeTrue)
+			So(coverage.NameSearchOutput.SelectAllMatchingLabel, ShouldEqual, "Add all 11 matching results")
+			So(coverage.NameSearchOutput.SelectAllLimitHint, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given more results match than can be added at once", t, func() {
+		tooMany := areas
+		tooMany.TotalCount = cfg.MaxSelectAllAreas + 1
+		coverage := m.CreateGetCoverage("City", "hart", "", "", "", "name-search", "dim", "city", "", tooMany, []model.SelectableElement{}, population.GetAreaTypeParentsResponse{}, false, 2)
+
+		Convey("Then the results cannot all be added and the limit is explained", func() {
+			So(coverage.NameSearchOutput.CanSelectAllMatching, ShouldBeFalse)
+			So(coverage.NameSearchOutput.SelectAllLimitHint, ShouldEqual, "Up to 500 results can be added at once")
+		})
+	})
+
+	Convey("Given adding every matching result failed", t, func() {
+		req := httptest.NewRequest("", "/filters/12345/dimensions/geography/coverage?c=name-search&q=hart&select-all-error=true", nil)
+		m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		coverage := m.CreateGetCoverage("City", "hart", "", "", "", "name-search", "dim", "city", "", areas, []model.SelectableElement{}, population.GetAreaTypeParentsResponse{}, false, 1)
+
+		Convey("Then the limit error is displayed", func() {
+			So(coverage.Page.Error.ErrorItems, ShouldHaveLength, 1)
+			So(coverage.Page.Error.ErrorItems[0].Description.Text, ShouldEqual, "Too many results, narrow down to 500 or fewer")
+		})
+	})
+}
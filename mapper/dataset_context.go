@@ -0,0 +1,82 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// DatasetContext represents the dataset and release which a filter customises
+type DatasetContext struct {
+	Dataset     filter.Dataset
+	Title       string
+	ReleaseDate string
+	IsCustom    bool
+	Newer       *NewerVersion
+}
+
+// NewerVersion represents the latest version of a dataset when it is newer than the version of the filter,
+// Missing lists the dimensions of the filter which it no longer has
+type NewerVersion struct {
+	Edition     string
+	Version     int
+	ReleaseDate string
+	Missing     []string
+}
+
+// SetDatasetContext sets the dataset and release shown on the pages mapped for the filter
+func (m *Mapper) SetDatasetContext(dataset DatasetContext) {
+	m.dataset = dataset
+}
+
+// mapDatasetContext maps the dataset and release of the filter to the context block of a page, the dataset id, title and
+// release date of the page are set for analytics. The landing page is not linked for custom datasets, which have none
+func (m *Mapper) mapDatasetContext(p *coreModel.Page) model.DatasetContext {
+	if m.dataset.Title == "" {
+		return model.DatasetContext{}
+	}
+
+	p.DatasetId = m.dataset.Dataset.DatasetID
+	p.DatasetTitle = m.dataset.Title
+	p.ReleaseDate = m.dataset.ReleaseDate
+
+	dc := model.DatasetContext{
+		Title:       m.dataset.Title,
+		Edition:     m.dataset.Dataset.Edition,
+		Version:     strconv.Itoa(m.dataset.Dataset.Version),
+		ReleaseDate: m.dataset.ReleaseDate,
+		Language:    m.lang,
+	}
+	if !m.dataset.IsCustom {
+		dc.URI = fmt.Sprintf("/datasets/%s/editions/%s/versions/%d", m.dataset.Dataset.DatasetID, m.dataset.Dataset.Edition, m.dataset.Dataset.Version)
+	}
+	return dc
+}
+
+// mapNewerVersion maps the latest version of the dataset when it is newer than the version of the filter,
+// the filter can only be rebuilt against it when none of its dimensions are missing
+func (m *Mapper) mapNewerVersion() model.NewerVersion {
+	newer := m.dataset.Newer
+	if newer == nil {
+		return model.NewerVersion{}
+	}
+
+	nv := model.NewerVersion{
+		HasNewerVersion: true,
+		Edition:         newer.Edition,
+		Version:         strconv.Itoa(newer.Version),
+		ReleaseDate:     newer.ReleaseDate,
+		URI:             fmt.Sprintf("/datasets/%s/editions/%s/versions/%d", m.dataset.Dataset.DatasetID, newer.Edition, newer.Version),
+		Missing:         []string{},
+	}
+	for _, label := range newer.Missing {
+		nv.Missing = append(nv.Missing, cleanDimensionLabel(label))
+	}
+	if len(nv.Missing) == 0 {
+		nv.RebuildURI = fmt.Sprintf("/filters/%s/rebuild", m.fid)
+	}
+	return nv
+}
@@ -0,0 +1,100 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestMapDatasetContext(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	ds := filter.Dataset{DatasetID: "dataset-id", Edition: "2021", Version: 2}
+
+	Convey("Given the dataset of a filter", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds, Title: "Dataset title", ReleaseDate: "2022-11-29T09:30:00.000Z"})
+		p := m.CreatePopulationTypeSelector(population.PopulationType{}, nil, nil)
+
+		Convey("Then the dataset context is mapped with a link to the landing page", func() {
+			So(p.DatasetContext.Title, ShouldEqual, "Dataset title")
+			So(p.DatasetContext.Edition, ShouldEqual, "2021")
+			So(p.DatasetContext.Version, ShouldEqual, "2")
+			So(p.DatasetContext.ReleaseDate, ShouldEqual, "2022-11-29T09:30:00.000Z")
+			So(p.DatasetContext.URI, ShouldEqual, "/datasets/dataset-id/editions/2021/versions/2")
+			So(p.DatasetContext.Language, ShouldEqual, "en")
+		})
+
+		Convey("Then the dataset is set for analytics", func() {
+			So(p.DatasetId, ShouldEqual, "dataset-id")
+			So(p.DatasetTitle, ShouldEqual, "Dataset title")
+			So(p.ReleaseDate, ShouldEqual, "2022-11-29T09:30:00.000Z")
+		})
+	})
+
+	Convey("Given a custom dataset", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds, Title: "Custom dataset", IsCustom: true})
+		p := m.CreatePopulationTypeSelector(population.PopulationType{}, nil, nil)
+
+		Convey("Then the landing page is not linked", func() {
+			So(p.DatasetContext.Title, ShouldEqual, "Custom dataset")
+			So(p.DatasetContext.URI, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the dataset could not be fetched", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds})
+		p := m.CreatePopulationTypeSelector(population.PopulationType{}, nil, nil)
+
+		Convey("Then the dataset context is empty", func() {
+			So(p.DatasetContext, ShouldBeZeroValue)
+			So(p.DatasetId, ShouldBeEmpty)
+		})
+	})
+}
+
+func TestMapNewerVersion(t *testing.T) {
+	ds := filter.Dataset{DatasetID: "dataset-id", Edition: "2021", Version: 1}
+
+	Convey("Given a newer version with every dimension of the filter", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds, Title: "Dataset title", Newer: &NewerVersion{Edition: "2021", Version: 2, ReleaseDate: "2023-01-01T00:00:00.000Z"}})
+		nv := m.mapNewerVersion()
+
+		Convey("Then the filter can be rebuilt against it", func() {
+			So(nv.HasNewerVersion, ShouldBeTrue)
+			So(nv.Version, ShouldEqual, "2")
+			So(nv.URI, ShouldEqual, "/datasets/dataset-id/editions/2021/versions/2")
+			So(nv.RebuildURI, ShouldEqual, "/filters/12345/rebuild")
+			So(nv.Missing, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given a newer version without some of the dimensions of the filter", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds, Title: "Dataset title", Newer: &NewerVersion{Edition: "2022", Version: 1, Missing: []string{"Sex (2 categories)"}}})
+		nv := m.mapNewerVersion()
+
+		Convey("Then the missing dimensions are listed and the filter cannot be rebuilt", func() {
+			So(nv.HasNewerVersion, ShouldBeTrue)
+			So(nv.Missing, ShouldResemble, []string{"Sex"})
+			So(nv.RebuildURI, ShouldBeEmpty)
+		})
+	})
+
+	Convey("Given the filter is based on the latest version", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: ds, Title: "Dataset title"})
+
+		Convey("Then no newer version is mapped", func() {
+			So(m.mapNewerVersion().HasNewerVersion, ShouldBeFalse)
+		})
+	})
+}
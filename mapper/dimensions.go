@@ -8,12 +8,13 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/topics"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 )
 
 // CreateGetChangeDimensions maps data to the ChangeDimensions model
-func (m *Mapper) CreateGetChangeDimensions(q, formAction string, dims []model.FilterDimension, pDims, results population.GetDimensionsResponse, sdc *cantabular.GetBlockedAreaCountResult) model.ChangeDimensions {
+func (m *Mapper) CreateGetChangeDimensions(q, formAction string, dims []model.FilterDimension, pDims, results population.GetDimensionsResponse, categoryMatches map[string]string, sdc *cantabular.GetBlockedAreaCountResult) model.ChangeDimensions {
 	cfg, _ := config.Get()
 
 	p := model.ChangeDimensions{
@@ -27,6 +28,7 @@ func (m *Mapper) CreateGetChangeDimensions(q, formAction string, dims []model.Fi
 	}
 	p.FormAction = formAction
 	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
 
 	selections := []model.SelectableElement{}
 	pageDims := []model.Dimension{}
@@ -66,10 +68,12 @@ func (m *Mapper) CreateGetChangeDimensions(q, formAction string, dims []model.Fi
 	}
 	mapCommonProps(m.req, &p.Page, "change_variables", title, m.lang, m.serviceMsg, m.eb)
 
-	browseResults := mapDimensionsResponse(pDims, &selections, m.lang)
-	searchResults := mapDimensionsResponse(results, &selections, m.lang)
+	browseResults := mapDimensionsResponse(pDims, nil, &selections, m.lang)
+	searchResults := rankDimensionResults(q, mapDimensionsResponse(results, categoryMatches, &selections, m.lang))
 
 	p.Output.Results = browseResults
+	p.SelectedTopic = m.req.URL.Query().Get("topic")
+	p.Topics, p.TopicOptions = mapTopicGroups(browseResults, p.SelectedTopic, m.lang)
 	p.SearchOutput.Results = searchResults
 	p.SearchOutput.HasNoResults = len(p.SearchOutput.Results) == 0 && formAction == "search"
 
@@ -125,3 +129,53 @@ func (m *Mapper) CreateGetChangeDimensions(q, formAction string, dims []model.Fi
 
 	return p
 }
+
+// mapTopicGroups groups the variables by topic in the order of the topic mapping, with variables which are not in the mapping grouped last.
+// When a known topic is selected only its group is returned, open, otherwise groups are open when they contain a selected variable.
+func mapTopicGroups(results []model.SelectableElement, selectedTopic, lang string) ([]model.TopicGroup, []model.TopicOption) {
+	groups := []model.TopicGroup{}
+	index := map[string]int{}
+	for _, result := range results {
+		topic, ok := topics.ForVariable(result.Value)
+		if !ok {
+			topic = topics.Topic{
+				ID:      topics.Other,
+				English: helper.Localise("DimensionsTopicOther", lang, 1),
+			}
+		}
+		i, ok := index[topic.ID]
+		if !ok {
+			i = len(groups)
+			index[topic.ID] = i
+			groups = append(groups, model.TopicGroup{
+				ID:       topic.ID,
+				Label:    topic.Label(lang),
+				Language: lang,
+			})
+		}
+		groups[i].Results = append(groups[i].Results, result)
+		groups[i].Count++
+		groups[i].IsOpen = groups[i].IsOpen || result.IsSelected
+	}
+	sort.SliceStable(groups, func(i, j int) bool {
+		return topics.Index(groups[i].ID) < topics.Index(groups[j].ID)
+	})
+
+	options := []model.TopicOption{}
+	for _, group := range groups {
+		options = append(options, model.TopicOption{
+			Value:      group.ID,
+			Label:      group.Label,
+			Count:      group.Count,
+			IsSelected: group.ID == selectedTopic,
+		})
+	}
+
+	for _, group := range groups {
+		if group.ID == selectedTopic {
+			group.IsOpen = true
+			return []model.TopicGroup{group}, options
+		}
+	}
+	return groups, options
+}
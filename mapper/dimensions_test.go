@@ -10,6 +10,7 @@ import (
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/topics"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 	. "github.com/smartystreets/goconvey/convey"
@@ -88,6 +89,7 @@ func TestGetChangeDimensions(t *testing.T) {
 				mockFds,
 				mockPds,
 				mockPdsR,
+				nil,
 				&cantabular.GetBlockedAreaCountResult{},
 			)
 			Convey("Then it maps page metadata", func() {
@@ -211,6 +213,7 @@ func TestGetChangeDimensions(t *testing.T) {
 				[]model.FilterDimension{},
 				population.GetDimensionsResponse{},
 				population.GetDimensionsResponse{},
+				nil,
 				&cantabular.GetBlockedAreaCountResult{},
 			)
 			Convey("then it sets HasNoResults to true", func() {
@@ -218,6 +221,34 @@ func TestGetChangeDimensions(t *testing.T) {
 			})
 		})
 
+		Convey("when a search matches variable names and categories", func() {
+			p := m.CreateGetChangeDimensions(
+				"age",
+				"search",
+				[]model.FilterDimension{},
+				population.GetDimensionsResponse{},
+				population.GetDimensionsResponse{
+					Dimensions: []population.Dimension{
+						{ID: "economic_activity", Label: "Economic activity status"},
+						{ID: "resident_age", Label: "Age of resident"},
+						{ID: "age", Label: "Age"},
+					},
+				},
+				map[string]string{"economic_activity": "Economically inactive: Aged 65 and over"},
+				&cantabular.GetBlockedAreaCountResult{},
+			)
+			Convey("then exact name matches are ranked first, followed by name and category matches", func() {
+				So(p.SearchOutput.Results, ShouldHaveLength, 3)
+				So(p.SearchOutput.Results[0].Value, ShouldEqual, "age")
+				So(p.SearchOutput.Results[1].Value, ShouldEqual, "resident_age")
+				So(p.SearchOutput.Results[2].Value, ShouldEqual, "economic_activity")
+			})
+			Convey("then it maps the matched category", func() {
+				So(p.SearchOutput.Results[0].MatchedCategory, ShouldBeEmpty)
+				So(p.SearchOutput.Results[2].MatchedCategory, ShouldEqual, "Economically inactive: Aged 65 and over")
+			})
+		})
+
 		Convey("when areas are blocked", func() {
 			mockSdc := cantabular.GetBlockedAreaCountResult{
 				Passed:  10,
@@ -230,6 +261,7 @@ func TestGetChangeDimensions(t *testing.T) {
 				[]model.FilterDimension{},
 				population.GetDimensionsResponse{},
 				population.GetDimensionsResponse{},
+				nil,
 				&mockSdc,
 			)
 			Convey("then it sets HasSDC to true", func() {
@@ -271,6 +303,7 @@ func TestGetChangeDimensions(t *testing.T) {
 				[]model.FilterDimension{},
 				population.GetDimensionsResponse{},
 				population.GetDimensionsResponse{},
+				nil,
 				&mockSdc,
 			)
 			Convey("then it sets MaxVariableError to true", func() {
@@ -294,6 +327,7 @@ func TestGetChangeDimensions(t *testing.T) {
 				[]model.FilterDimension{},
 				population.GetDimensionsResponse{},
 				population.GetDimensionsResponse{},
+				nil,
 				&mockSdc,
 			)
 			Convey("then it sets HasSDC to true", func() {
@@ -326,3 +360,60 @@ func TestGetChangeDimensions(t *testing.T) {
 		})
 	})
 }
+
+func TestMapTopicGroups(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	results := []model.SelectableElement{
+		{Text: "Accommodation type", Value: "accommodation_type_8a"},
+		{Text: "Age", Value: "resident_age_101a"},
+		{Text: "Custom variable", Value: "custom_variable"},
+		{Text: "Sex", Value: "sex", IsSelected: true},
+	}
+
+	Convey("Given no topic is selected", t, func() {
+		groups, options := mapTopicGroups(results, "", "en")
+
+		Convey("Then the variables are grouped in the order of the topic mapping with other variables last", func() {
+			So(groups, ShouldHaveLength, 3)
+			So(groups[0].ID, ShouldEqual, "demography")
+			So(groups[0].Label, ShouldEqual, "Demography and migration")
+			So(groups[0].Count, ShouldEqual, 2)
+			So(groups[0].Results[0].Value, ShouldEqual, "resident_age_101a")
+			So(groups[1].ID, ShouldEqual, "housing")
+			So(groups[2].ID, ShouldEqual, topics.Other)
+			So(groups[2].Label, ShouldEqual, "Other variables")
+		})
+
+		Convey("Then groups containing a selected variable are open", func() {
+			So(groups[0].IsOpen, ShouldBeTrue)
+			So(groups[1].IsOpen, ShouldBeFalse)
+		})
+
+		Convey("Then a topic option with a count is returned for each group", func() {
+			So(options, ShouldResemble, []model.TopicOption{
+				{Value: "demography", Label: "Demography and migration", Count: 2},
+				{Value: "housing", Label: "Housing", Count: 1},
+				{Value: topics.Other, Label: "Other variables", Count: 1},
+			})
+		})
+	})
+
+	Convey("Given a topic is selected", t, func() {
+		groups, options := mapTopicGroups(results, "housing", "cy")
+
+		Convey("Then only the open group of the topic is returned", func() {
+			So(groups, ShouldHaveLength, 1)
+			So(groups[0].Label, ShouldEqual, "Tai")
+			So(groups[0].IsOpen, ShouldBeTrue)
+			So(options[1].IsSelected, ShouldBeTrue)
+		})
+	})
+
+	Convey("Given an unknown topic is selected", t, func() {
+		groups, _ := mapTopicGroups(results, "unknown", "en")
+
+		Convey("Then every group is returned", func() {
+			So(groups, ShouldHaveLength, 3)
+		})
+	})
+}
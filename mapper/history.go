@@ -0,0 +1,45 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+)
+
+// CreateRecentFilters maps data to the RecentFilters model
+func (m *Mapper) CreateRecentFilters(items []model.FilterHistoryItem) model.RecentFilters {
+	cfg, _ := config.Get()
+
+	p := model.RecentFilters{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, recentFiltersPageType, helper.Localise("RecentFiltersTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+
+	for _, item := range items {
+		rf := model.RecentFilter{
+			FilterID:     item.FilterID,
+			DatasetTitle: item.DatasetTitle,
+			IsExpired:    item.IsExpired,
+			IsSubmitted:  item.Filter.State == submittedState,
+		}
+		if !item.LastChanged.IsZero() {
+			rf.LastChanged = helper.DateTimeFormat(item.LastChanged.Format(time.RFC3339))
+		}
+		if rf.DatasetTitle == "" {
+			rf.DatasetTitle = helper.Localise("RecentFiltersUnknownDataset", m.lang, 1)
+		}
+		if !item.IsExpired {
+			rf.URI = fmt.Sprintf("/filters/%s/dimensions", item.FilterID)
+		}
+		for _, dim := range item.Dimensions {
+			rf.Dimensions = append(rf.Dimensions, cleanDimensionLabel(dim.Label))
+		}
+		p.Filters = append(p.Filters, rf)
+	}
+
+	return p
+}
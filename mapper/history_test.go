@@ -0,0 +1,55 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateRecentFilters(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/filters/recent", nil)
+	m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "")
+	modified := time.Date(2022, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	Convey("Given a filter history", t, func() {
+		items := []model.FilterHistoryItem{
+			{
+				FilterID:     "1",
+				LastChanged:  modified,
+				Filter:       filter.GetFilterResponse{State: "submitted"},
+				Dimensions:   []filter.Dimension{{Label: "Sex (2 categories)"}, {Label: "Age"}},
+				DatasetTitle: "Example dataset",
+			},
+			{
+				FilterID:  "2",
+				IsExpired: true,
+			},
+		}
+		p := m.CreateRecentFilters(items)
+
+		Convey("Then the page type is mapped", func() {
+			So(p.Type, ShouldEqual, recentFiltersPageType)
+		})
+		Convey("Then available filters link to the overview", func() {
+			So(p.Filters[0].URI, ShouldEqual, "/filters/1/dimensions")
+			So(p.Filters[0].DatasetTitle, ShouldEqual, "Example dataset")
+			So(p.Filters[0].Dimensions, ShouldResemble, []string{"Sex", "Age"})
+			So(p.Filters[0].LastChanged, ShouldEqual, "01 March 2022 09:30")
+			So(p.Filters[0].IsSubmitted, ShouldBeTrue)
+		})
+		Convey("Then expired filters are marked and not linked", func() {
+			So(p.Filters[1].IsExpired, ShouldBeTrue)
+			So(p.Filters[1].LastChanged, ShouldBeEmpty)
+			So(p.Filters[1].URI, ShouldBeEmpty)
+			So(p.Filters[1].DatasetTitle, ShouldEqual, "Unknown dataset")
+		})
+	})
+}
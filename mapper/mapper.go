@@ -14,18 +14,21 @@ import (
 	"github.com/ONSdigital/dp-cookies/cookies"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 )
 
 // Mapper represents the core mappings required for all pages
 type Mapper struct {
-	req        *http.Request
-	basePage   coreModel.Page
-	eb         zebedee.EmergencyBanner
-	lang       string
-	serviceMsg string
-	fid        string
+	req          *http.Request
+	basePage     coreModel.Page
+	eb           zebedee.EmergencyBanner
+	lang         string
+	serviceMsg   string
+	fid          string
+	dataset      DatasetContext
+	browseParent BrowseParent
 }
 
 // NewMapper creates a new instance of Mapper
@@ -48,24 +51,32 @@ const (
 	pluralInt             = 4
 	nameSearch            = "name-search"
 	parentSearch          = "parent-search"
+	browse                = "browse"
+	browseOptionName      = "add-browse-option"
 	nameSearchFieldName   = "q"
 	parentSearchFieldName = "pq"
 	coveragePageType      = "coverage_options"
 	coverageTitle         = "Coverage"
 	areaPageType          = "area_type_options"
 	reviewPageType        = "review_changes"
+	sdcAreasPageType      = "sdc_areas"
+	recentFiltersPageType = "recent_filters"
+	comparePageType       = "filter_comparison"
+	submittedState        = "submitted"
 	maxVariableErrorStr   = "Maximum variables"
 	maxCellsErrorStr      = "withinMaxCells"
+	maxSuggestions        = 5
 )
 
-// mapDimensionsResponse returns a sorted array of selectable elements
-func mapDimensionsResponse(pDims population.GetDimensionsResponse, selections *[]model.SelectableElement, lang string) []model.SelectableElement {
+// mapDimensionsResponse returns a sorted array of selectable elements with the category which matched a search, keyed by dimension id
+func mapDimensionsResponse(pDims population.GetDimensionsResponse, categoryMatches map[string]string, selections *[]model.SelectableElement, lang string) []model.SelectableElement {
 	results := []model.SelectableElement{}
 	for _, pDim := range pDims.Dimensions {
 		var sel model.SelectableElement
 		sel.Name = "add-dimension"
 		sel.Text = cleanDimensionLabel(pDim.Label)
 		sel.InnerText = pDim.Description
+		sel.MatchedCategory = categoryMatches[pDim.ID]
 		sel.Value = pDim.ID
 		sel.QualityStatement = model.Panel{
 			CssClasses: []string{"ons-u-mt-s", "ons-u-mb-xs"},
@@ -90,6 +101,24 @@ func mapDimensionsResponse(pDims population.GetDimensionsResponse, selections *[
 	return results
 }
 
+// rankDimensionResults orders search results with exact name matches first, then other name matches, then category matches
+func rankDimensionResults(q string, results []model.SelectableElement) []model.SelectableElement {
+	query := strings.Join(strings.Fields(search.Normalise(q)), " ")
+	rank := func(sel model.SelectableElement) int {
+		switch {
+		case strings.Join(strings.Fields(search.Normalise(sel.Text)), " ") == query:
+			return 0
+		case sel.MatchedCategory == "":
+			return 1
+		}
+		return 2
+	}
+	sort.SliceStable(results, func(i, j int) bool {
+		return rank(results[i]) < rank(results[j])
+	})
+	return results
+}
+
 // cleanDimensionLabel is a helper function that parses dimension labels from cantabular into display text
 func cleanDimensionLabel(label string) string {
 	matcher := regexp.MustCompile(`(\(\d+ ((C|c)ategories|(C|c)ategory)\))`)
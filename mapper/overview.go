@@ -17,7 +17,7 @@ import (
 )
 
 // CreateFilterFlexOverview maps data to the Overview model
-func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, filterDims []model.FilterDimension, dimDescriptions population.GetDimensionsResponse, pops population.GetPopulationTypeResponse, sdc cantabular.GetBlockedAreaCountResult, isMultivariate bool) model.Overview {
+func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, filterDims []model.FilterDimension, dimDescriptions population.GetDimensionsResponse, pops population.GetPopulationTypeResponse, sdc cantabular.GetBlockedAreaCountResult, suggestions []model.SDCSuggestion, isMultivariate bool) model.Overview {
 	cfg, _ := config.Get()
 
 	queryStrValues := m.req.URL.Query()["showAll"]
@@ -37,6 +37,8 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 	dataset := filterJob.Dataset
 	p.IsMultivariate = isMultivariate
 	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	p.NewerVersion = m.mapNewerVersion()
 
 	p.Breadcrumb = buildBreadcrumb(dataset, helpers.IsBoolPtr(filterJob.Custom), m.lang)
 
@@ -45,6 +47,8 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 		ID:          pops.PopulationType.Name,
 		Options:     []string{pops.PopulationType.Label},
 		IsGeography: true,
+		HasChange:   helpers.IsBoolPtr(filterJob.Custom),
+		URI:         fmt.Sprintf("/filters/%s/population-type", filterJob.FilterID),
 	}
 
 	coverage := model.Dimension{
@@ -56,7 +60,9 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 	}
 
 	var area model.Dimension
+	var areaNotes, notes []model.QualityNote
 	for _, dim := range filterDims {
+		quality, note := m.mapQualityNote(dim.Dimension)
 		if *dim.IsAreaType {
 			area.Name = helper.Localise("AreaTypeDescription", m.lang, 1)
 			area.Options = []string{cleanDimensionLabel(dim.Label)}
@@ -66,6 +72,11 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 			area.ID = dim.ID
 			area.URI = fmt.Sprintf("%s/%s", path, dim.Name)
 			area.HasChange = true
+			area.Quality = quality
+			area.QualityURI = dim.QualitySummaryURL
+			if note != nil {
+				areaNotes = append(areaNotes, *note)
+			}
 		} else {
 			pageDim := model.Dimension{}
 			pageDim.Name = cleanDimensionLabel(dim.Label)
@@ -74,7 +85,13 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 			pageDim.ID = dim.ID
 			pageDim.URI = fmt.Sprintf("%s/%s", path, dim.Name)
 			pageDim.HasChange = isMultivariate && dim.CategorisationCount > 1
+			pageDim.CategoriesURI = fmt.Sprintf("%s/%s/categories", path, dim.Name)
 			pageDim.HasCategories = true
+			pageDim.Quality = quality
+			pageDim.QualityURI = dim.QualitySummaryURL
+			if note != nil {
+				notes = append(notes, *note)
+			}
 			q := url.Values{}
 			midFloor, midCeiling := getTruncationMidRange(dim.OptionsCount)
 
@@ -102,15 +119,19 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 		coverage.Options = []string{helper.Localise("AreaTypeDefaultCoverage", m.lang, 1)}
 	}
 
-	sort.Slice(p.Dimensions, func(i, j int) bool {
-		return p.Dimensions[i].Name < p.Dimensions[j].Name
-	})
+	// variables are displayed in the order of the filter, which is the order of the output table
+	for i := range p.Dimensions {
+		p.Dimensions[i].CanMoveUp = i > 0
+		p.Dimensions[i].CanMoveDown = i < len(p.Dimensions)-1
+		p.Dimensions[i].ReorderURI = fmt.Sprintf("%s/reorder", path)
+	}
 
 	p.Dimensions = append([]model.Dimension{
 		pop,
 		area,
 		coverage,
 	}, p.Dimensions...)
+	p.QualityNotes = append(areaNotes, notes...)
 
 	p.DimensionDescriptions = coreModel.Collapsible{
 		Title: coreModel.Localisation{
@@ -126,6 +147,9 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 		case sdc.Blocked > 0 || maxCellsError: // areas blocked
 			p.HasSDC = true
 			p.Panel = *m.mapBlockedAreasPanel(&sdc, maxCellsError, model.Pending)
+			if !maxCellsError {
+				p.BlockedAreasURI = fmt.Sprintf("/filters/%s/sdc/areas", m.fid)
+			}
 
 			areaTypeUri, dimNames := mapImproveResultsCollapsible(p.Dimensions)
 			p.ImproveResults = coreModel.Collapsible{
@@ -142,6 +166,7 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 					},
 				},
 			}
+			p.Suggestions = m.mapSDCSuggestions(suggestions, &sdc)
 		case sdc.Passed == sdc.Total && sdc.Total > 0: // all areas passing
 			p.HasSDC = true
 			p.Panel = *m.mapBlockedAreasPanel(&sdc, maxCellsError, model.Success)
@@ -176,6 +201,91 @@ func (m *Mapper) CreateFilterFlexOverview(filterJob filter.GetFilterResponse, fi
 	return p
 }
 
+// mapQualityNote maps the quality statement of a variable to a warning, the note is nil when it has neither a statement nor a summary
+func (m *Mapper) mapQualityNote(dim filter.Dimension) (model.Panel, *model.QualityNote) {
+	if dim.QualityStatementText == "" && dim.QualitySummaryURL == "" {
+		return model.Panel{}, nil
+	}
+
+	quality := model.Panel{
+		Type:       model.Warn,
+		CssClasses: []string{"ons-u-mt-s", "ons-u-mb-xs"},
+		Body:       dim.QualityStatementText,
+		Language:   m.lang,
+	}
+	return quality, &model.QualityNote{
+		Dimension: cleanDimensionLabel(dim.Label),
+		Text:      dim.QualityStatementText,
+		URI:       dim.QualitySummaryURL,
+	}
+}
+
+// mapSDCSuggestions ranks the categorisation suggestions by the number of areas they unlock and the area type suggestions
+// by the share of their areas which are available, as a larger area type is evaluated against a different number of areas
+func (m *Mapper) mapSDCSuggestions(suggestions []model.SDCSuggestion, sdc *cantabular.GetBlockedAreaCountResult) []model.Suggestion {
+	var categorisations, areaTypes []model.SDCSuggestion
+	for _, s := range suggestions {
+		if s.IsAreaType {
+			areaTypes = append(areaTypes, s)
+			continue
+		}
+		categorisations = append(categorisations, s)
+	}
+
+	// every categorisation is evaluated against the same coverage, so they are ranked by the number of areas they unblock
+	unlocked := func(s model.SDCSuggestion) int {
+		return sdc.Blocked - s.Blocked
+	}
+	sort.SliceStable(categorisations, func(i, j int) bool {
+		if unlocked(categorisations[i]) != unlocked(categorisations[j]) {
+			return unlocked(categorisations[i]) > unlocked(categorisations[j])
+		}
+		return categorisations[i].Label < categorisations[j].Label
+	})
+	sort.SliceStable(areaTypes, func(i, j int) bool {
+		// compares passed/total of each without dividing
+		a := areaTypes[i].Passed * areaTypes[j].Total
+		b := areaTypes[j].Passed * areaTypes[i].Total
+		if a != b {
+			return a > b
+		}
+		return areaTypes[i].Label < areaTypes[j].Label
+	})
+
+	if len(categorisations) > maxSuggestions {
+		categorisations = categorisations[:maxSuggestions]
+	}
+	if len(areaTypes) > maxSuggestions {
+		areaTypes = areaTypes[:maxSuggestions]
+	}
+
+	var mapped []model.Suggestion
+	for _, s := range categorisations {
+		n := unlocked(s)
+		mapped = append(mapped, model.Suggestion{
+			Text: helper.Localise("Suggestion", m.lang, n,
+				cleanDimensionLabel(s.CurrentLabel),
+				cleanDimensionLabel(s.Label),
+				helper.ThousandsSeparator(n)),
+			URI:       fmt.Sprintf("/filters/%s/dimensions/%s", m.fid, s.Dimension),
+			Dimension: s.Value,
+		})
+	}
+	for _, s := range areaTypes {
+		mapped = append(mapped, model.Suggestion{
+			Text: helper.Localise("SuggestionAreaType", m.lang, s.Total,
+				cleanDimensionLabel(s.CurrentLabel),
+				cleanDimensionLabel(s.Label),
+				helper.ThousandsSeparator(s.Passed),
+				helper.ThousandsSeparator(s.Total)),
+			URI:        fmt.Sprintf("/filters/%s/dimensions/%s", m.fid, s.Dimension),
+			Dimension:  s.Value,
+			IsAreaType: true,
+		})
+	}
+	return mapped
+}
+
 func buildBreadcrumb(dataset filter.Dataset, isCustom bool, lang string) []coreModel.TaxonomyNode {
 	if isCustom {
 		return []coreModel.TaxonomyNode{
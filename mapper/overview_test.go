@@ -145,7 +145,7 @@ func TestOverview(t *testing.T) {
 	}
 
 	Convey("test filter flex overview maps correctly", t, func() {
-		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.BetaBannerEnabled, ShouldBeTrue)
 		So(overview.Type, ShouldEqual, "review_changes")
 		So(overview.Metadata.Title, ShouldEqual, "Review changes")
@@ -162,6 +162,7 @@ func TestOverview(t *testing.T) {
 		So(overview.Dimensions[0].Options[0], ShouldEqual, pop.PopulationType.Label)
 		So(overview.Dimensions[0].ID, ShouldEqual, pop.PopulationType.Name)
 		So(overview.Dimensions[0].IsGeography, ShouldBeTrue)
+		So(overview.Dimensions[0].HasChange, ShouldBeFalse)
 
 		So(overview.Dimensions[1].Name, ShouldEqual, "Area type")
 		So(overview.Dimensions[1].IsGeography, ShouldBeTrue)
@@ -203,17 +204,20 @@ func TestOverview(t *testing.T) {
 				Edition:   "2021",
 				Version:   1,
 			},
+			FilterID:       "12345",
 			PopulationType: "UR",
 			Custom:         helpers.ToBoolPtr(true),
 		}
-		overview := m.CreateFilterFlexOverview(customFilterJob, filterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(customFilterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.Metadata.Title, ShouldEqual, "Custom dataset")
 		So(overview.Breadcrumb[0].Title, ShouldEqual, "Start again - Create a custom dataset")
 		So(overview.Breadcrumb[0].URI, ShouldEqual, "/datasets/create")
+		So(overview.Dimensions[0].HasChange, ShouldBeTrue)
+		So(overview.Dimensions[0].URI, ShouldEqual, "/filters/12345/population-type")
 	})
 
 	Convey("test truncation maps as expected", t, func() {
-		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.Dimensions[4].OptionsCount, ShouldEqual, filterDims[1].OptionsCount)
 		So(overview.Dimensions[4].Options, ShouldHaveLength, 9)
 		So(overview.Dimensions[4].Options[:3], ShouldResemble, []string{"Opt 1", "Opt 2", "Opt 3"})
@@ -231,14 +235,33 @@ func TestOverview(t *testing.T) {
 
 	Convey("test truncation shows all when parameter given", t, func() {
 		m.req = httptest.NewRequest("", "/?showAll=Truncated+dim+2", nil)
-		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.Dimensions[5].OptionsCount, ShouldEqual, filterDims[2].OptionsCount)
 		So(overview.Dimensions[5].Options, ShouldHaveLength, 12)
 		So(overview.Dimensions[5].IsTruncated, ShouldBeFalse)
 	})
 
+	Convey("test variables are mapped in filter order with reorder controls", t, func() {
+		reversedDims := []model.FilterDimension{filterDims[2], filterDims[3], filterDims[1], filterDims[0]}
+		overview := m.CreateFilterFlexOverview(filterJob, reversedDims, dimDescriptions, pop, sdc, nil, false)
+		So(overview.Dimensions[3].URI, ShouldEqual, fmt.Sprintf("%s/%s", m.req.URL.Path, filterDims[2].Name))
+		So(overview.Dimensions[4].URI, ShouldEqual, fmt.Sprintf("%s/%s", m.req.URL.Path, filterDims[1].Name))
+		So(overview.Dimensions[5].URI, ShouldEqual, fmt.Sprintf("%s/%s", m.req.URL.Path, filterDims[0].Name))
+
+		So(overview.Dimensions[3].CanMoveUp, ShouldBeFalse)
+		So(overview.Dimensions[3].CanMoveDown, ShouldBeTrue)
+		So(overview.Dimensions[4].CanMoveUp, ShouldBeTrue)
+		So(overview.Dimensions[4].CanMoveDown, ShouldBeTrue)
+		So(overview.Dimensions[5].CanMoveUp, ShouldBeTrue)
+		So(overview.Dimensions[5].CanMoveDown, ShouldBeFalse)
+		So(overview.Dimensions[5].ReorderURI, ShouldEqual, fmt.Sprintf("%s/reorder", m.req.URL.Path))
+
+		So(overview.Dimensions[0].ReorderURI, ShouldBeEmpty)
+		So(overview.Dimensions[1].ReorderURI, ShouldBeEmpty)
+	})
+
 	Convey("test area type dimension options do not truncate and map to 'coverage' dimension", t, func() {
-		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.Dimensions[2].Options, ShouldHaveLength, 10)
 		So(overview.Dimensions[2].IsTruncated, ShouldBeFalse)
 		So(overview.Dimensions[2].IsGeography, ShouldBeTrue)
@@ -255,20 +278,56 @@ func TestOverview(t *testing.T) {
 			},
 		}...)
 
-		overview := m.CreateFilterFlexOverview(filterJob, newFilterDims, dimDescriptions, pop, sdc, false)
+		overview := m.CreateFilterFlexOverview(filterJob, newFilterDims, dimDescriptions, pop, sdc, nil, false)
 		So(overview.Dimensions[6].Name, ShouldEqual, "Example")
 	})
 
+	Convey("Given variables with quality statements", t, func() {
+		qualityDims := append([]model.FilterDimension{}, filterDims...)
+		qualityDims[0].Dimension.Label = "Sex (2 categories)"
+		qualityDims[0].Dimension.QualityStatementText = "Sex statement"
+		qualityDims[0].Dimension.QualitySummaryURL = "/sex-quality"
+		qualityDims[3].Dimension.QualitySummaryURL = "/area-quality"
+		overview := m.CreateFilterFlexOverview(filterJob, qualityDims, dimDescriptions, pop, sdc, nil, false)
+
+		Convey("Then the quality statement is shown as a warning next to the variable", func() {
+			So(overview.Dimensions[3].Quality.Body, ShouldEqual, "Sex statement")
+			So(overview.Dimensions[3].Quality.FuncGetPanelType(), ShouldEqual, "warn")
+			So(overview.Dimensions[3].QualityURI, ShouldEqual, "/sex-quality")
+			So(overview.Dimensions[1].Quality.Body, ShouldBeEmpty)
+			So(overview.Dimensions[1].QualityURI, ShouldEqual, "/area-quality")
+			So(overview.Dimensions[4].Quality.Body, ShouldBeEmpty)
+		})
+
+		Convey("Then the quality notes of the table are combined with the area type first", func() {
+			So(overview.QualityNotes, ShouldHaveLength, 2)
+			So(overview.QualityNotes[0].URI, ShouldEqual, "/area-quality")
+			So(overview.QualityNotes[1], ShouldResemble, model.QualityNote{
+				Dimension: "Sex",
+				Text:      "Sex statement",
+				URI:       "/sex-quality",
+			})
+		})
+	})
+
+	Convey("Given variables without quality statements", t, func() {
+		overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
+
+		Convey("Then there are no quality notes", func() {
+			So(overview.QualityNotes, ShouldBeEmpty)
+		})
+	})
+
 	Convey("Given area type selection", t, func() {
 		Convey("When area types are selected", func() {
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 			Convey("Then area selection is displayed", func() {
 				So(overview.Dimensions[2].Options, ShouldResemble, filterDims[3].Options)
 			})
 		})
 		Convey("When no area types are selected", func() {
 			filterDims[3].Options = []string{}
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 			Convey("Then the default coverage is displayed", func() {
 				So(overview.Dimensions[2].Options[0], ShouldResemble, "England and Wales")
 			})
@@ -280,7 +339,7 @@ func TestOverview(t *testing.T) {
 			sdc.Blocked = 10
 			sdc.Passed = 15
 			sdc.Total = 25
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, true)
 			Convey("Then the bool isMultivariate is true", func() {
 				So(overview.IsMultivariate, ShouldBeTrue)
 			})
@@ -299,6 +358,9 @@ func TestOverview(t *testing.T) {
 			Convey("Then the 'how to improve your results collapsible' is populated", func() {
 				So(overview.ImproveResults.CollapsibleItems, ShouldHaveLength, 1)
 			})
+			Convey("Then the blocked areas link is displayed", func() {
+				So(overview.BlockedAreasURI, ShouldEqual, "/filters/12345/sdc/areas")
+			})
 			Convey("Then the Get Data button is enabled", func() {
 				So(overview.DisableGetDataButton, ShouldBeFalse)
 			})
@@ -311,7 +373,7 @@ func TestOverview(t *testing.T) {
 				Total:      0,
 				TableError: "withinMaxCells",
 			}
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, maxCellsSdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, maxCellsSdc, nil, true)
 			Convey("Then the sdc bool is true", func() {
 				So(overview.HasSDC, ShouldBeTrue)
 			})
@@ -333,7 +395,7 @@ func TestOverview(t *testing.T) {
 			sdc.Blocked = 0
 			sdc.Passed = 25
 			sdc.Total = 25
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, true)
 			Convey("Then the bool isMultivariate is true", func() {
 				So(overview.IsMultivariate, ShouldBeTrue)
 			})
@@ -363,12 +425,98 @@ func TestOverview(t *testing.T) {
 				Passed:  0,
 				Total:   25,
 			}
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, nil, true)
 			Convey("Then the Get Data button is disabled", func() {
 				So(overview.DisableGetDataButton, ShouldBeTrue)
 			})
 		})
 
+		Convey("When suggestions are given for blocked areas", func() {
+			mockSdc := cantabular.GetBlockedAreaCountResult{
+				Blocked: 10,
+				Passed:  15,
+				Total:   25,
+			}
+			suggestions := []model.SDCSuggestion{
+				{Dimension: "ltla", Value: "rgn", Label: "Regions", CurrentLabel: "Lower tier local authorities", IsAreaType: true, Passed: 5, Blocked: 2, Total: 7},
+				{Dimension: "Dim 1", Value: "dim_1a", Label: "Dim 1a (3 categories)", CurrentLabel: "Dim 1 (6 categories)", Passed: 16, Blocked: 9, Total: 25},
+				{Dimension: "Dim 2", Value: "dim_2a", Label: "Dim 2a (2 categories)", CurrentLabel: "Dim 2 (4 categories)", Passed: 20, Blocked: 5, Total: 25},
+				{Dimension: "Dim 3", Value: "dim_3a", Label: "Dim 3a", CurrentLabel: "Dim 3", Passed: 17, Blocked: 8, Total: 25},
+				{Dimension: "Dim 4", Value: "dim_4a", Label: "Dim 4a", CurrentLabel: "Dim 4", Passed: 17, Blocked: 8, Total: 25},
+				{Dimension: "Dim 5", Value: "dim_5a", Label: "Dim 5a", CurrentLabel: "Dim 5", Passed: 18, Blocked: 7, Total: 25},
+			}
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, suggestions, true)
+			Convey("Then the number of categorisation suggestions is capped", func() {
+				So(overview.Suggestions, ShouldHaveLength, maxSuggestions+1)
+			})
+			Convey("Then categorisations are ranked by the number of areas unlocked ahead of the area types", func() {
+				So(overview.Suggestions[0].Dimension, ShouldEqual, "dim_2a")
+				So(overview.Suggestions[1].Dimension, ShouldEqual, "dim_5a")
+				So(overview.Suggestions[2].Dimension, ShouldEqual, "dim_3a")
+				So(overview.Suggestions[3].Dimension, ShouldEqual, "dim_4a")
+				So(overview.Suggestions[4].Dimension, ShouldEqual, "dim_1a")
+				So(overview.Suggestions[5].Dimension, ShouldEqual, "rgn")
+				So(overview.Suggestions[5].IsAreaType, ShouldBeTrue)
+			})
+			Convey("Then suggestions map text and uri", func() {
+				So(overview.Suggestions[0].Text, ShouldEqual, "Switch Dim 2 to Dim 2a to unlock 5 more areas")
+				So(overview.Suggestions[0].URI, ShouldEqual, "/filters/12345/dimensions/Dim 2")
+				So(overview.Suggestions[5].Text, ShouldEqual, "Switch Lower tier local authorities to Regions where 5 of 7 areas are available")
+				So(overview.Suggestions[5].URI, ShouldEqual, "/filters/12345/dimensions/ltla")
+			})
+		})
+
+		Convey("When more than one area type is suggested", func() {
+			mockSdc := cantabular.GetBlockedAreaCountResult{
+				Blocked: 10,
+				Passed:  15,
+				Total:   25,
+			}
+			suggestions := []model.SDCSuggestion{
+				{Dimension: "ltla", Value: "ctry", Label: "Countries", CurrentLabel: "Lower tier local authorities", IsAreaType: true, Passed: 1, Blocked: 0, Total: 1},
+				{Dimension: "ltla", Value: "rgn", Label: "Regions", CurrentLabel: "Lower tier local authorities", IsAreaType: true, Passed: 5, Blocked: 2, Total: 7},
+				{Dimension: "ltla", Value: "utla", Label: "Upper tier local authorities", CurrentLabel: "Lower tier local authorities", IsAreaType: true, Passed: 9, Blocked: 1, Total: 10},
+			}
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, suggestions, true)
+			Convey("Then they are ranked by the share of their areas which are available", func() {
+				So(overview.Suggestions, ShouldHaveLength, 3)
+				So(overview.Suggestions[0].Dimension, ShouldEqual, "ctry")
+				So(overview.Suggestions[0].Text, ShouldEqual, "Switch Lower tier local authorities to Countries where 1 of 1 area is available")
+				So(overview.Suggestions[1].Dimension, ShouldEqual, "utla")
+				So(overview.Suggestions[2].Dimension, ShouldEqual, "rgn")
+			})
+		})
+
+		Convey("When a suggestion unlocks one area", func() {
+			mockSdc := cantabular.GetBlockedAreaCountResult{
+				Blocked: 10,
+				Passed:  15,
+				Total:   25,
+			}
+			suggestions := []model.SDCSuggestion{
+				{Dimension: "Dim 1", Value: "dim_1a", Label: "Dim 1a", CurrentLabel: "Dim 1", Passed: 16, Blocked: 9, Total: 25},
+			}
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, suggestions, true)
+			Convey("Then the singular text is mapped", func() {
+				So(overview.Suggestions, ShouldHaveLength, 1)
+				So(overview.Suggestions[0].Text, ShouldEqual, "Switch Dim 1 to Dim 1a to unlock 1 more area")
+			})
+		})
+
+		Convey("When suggestions are given and no areas are blocked", func() {
+			mockSdc := cantabular.GetBlockedAreaCountResult{
+				Passed: 25,
+				Total:  25,
+			}
+			suggestions := []model.SDCSuggestion{
+				{Dimension: "Dim 1", Value: "dim_1a", Label: "Dim 1a", Passed: 25, Total: 25},
+			}
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, suggestions, true)
+			Convey("Then no suggestions are mapped", func() {
+				So(overview.Suggestions, ShouldBeEmpty)
+			})
+		})
+
 		Convey("when maximum variable count is exceeded", func() {
 			mockSdc := cantabular.GetBlockedAreaCountResult{
 				Blocked:    0,
@@ -376,7 +524,7 @@ func TestOverview(t *testing.T) {
 				Total:      0,
 				TableError: "Maximum variables exceeded",
 			}
-			p := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, true)
+			p := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, mockSdc, nil, true)
 			Convey("then it sets MaxVariableError to true", func() {
 				So(p.MaxVariableError, ShouldBeTrue)
 			})
@@ -391,7 +539,7 @@ func TestOverview(t *testing.T) {
 
 	Convey("test IsChangeVisible parameter", t, func() {
 		Convey("when isMultivariate is false", func() {
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 			Convey("then IsChangeCategories is false for all", func() {
 				So(overview.Dimensions[3].HasChange, ShouldBeFalse)
 				So(overview.Dimensions[4].HasChange, ShouldBeFalse)
@@ -400,7 +548,7 @@ func TestOverview(t *testing.T) {
 		})
 
 		Convey("when isMultivariate is true", func() {
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, true)
 			Convey("then IsChangeCategories is false if categorisation is only one available", func() {
 				So(overview.Dimensions[3].HasChange, ShouldBeFalse)
 				So(overview.Dimensions[4].HasChange, ShouldBeTrue)
@@ -411,14 +559,14 @@ func TestOverview(t *testing.T) {
 
 	Convey("test ShowGetDataButton boolean", t, func() {
 		Convey("when isMultivariate is false", func() {
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, false)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, false)
 			Convey("then ShowGetDataButton should be true", func() {
 				So(overview.ShowGetDataButton, ShouldBeTrue)
 			})
 		})
 
 		Convey("when isMultivariate is true and one or more dimensions are added", func() {
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, true)
 			Convey("then ShowGetDataButton should be true", func() {
 				So(overview.ShowGetDataButton, ShouldBeTrue)
 			})
@@ -447,7 +595,7 @@ func TestOverview(t *testing.T) {
 					CategorisationCount: 2,
 				},
 			}
-			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, true)
+			overview := m.CreateFilterFlexOverview(filterJob, filterDims, dimDescriptions, pop, sdc, nil, true)
 			Convey("then ShowGetDataButton should be false", func() {
 				So(overview.ShowGetDataButton, ShouldBeFalse)
 			})
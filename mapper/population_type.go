@@ -0,0 +1,74 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// PopulationTypeChange represents the variables and area type of a filter which are kept when its population type changes
+type PopulationTypeChange struct {
+	PopulationType    population.PopulationType
+	Kept              []filter.Dimension
+	Dropped           []filter.Dimension
+	DroppedAreas      []population.Area
+	AreaType          population.AreaType
+	IsAreaTypeChanged bool
+}
+
+// CreatePopulationTypeSelector maps data to the PopulationTypeSelector model, the change is reviewed when it is set
+func (m *Mapper) CreatePopulationTypeSelector(current population.PopulationType, populationTypes []population.PopulationType, change *PopulationTypeChange) model.PopulationTypeSelector {
+	cfg, _ := config.Get()
+
+	p := model.PopulationTypeSelector{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, "filter-flex-population-type", helper.Localise("PopulationTypeTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.Breadcrumb = []coreModel.TaxonomyNode{
+		{
+			Title: helper.Localise("Back", m.lang, 1),
+			URI:   fmt.Sprintf("/filters/%s/dimensions", m.fid),
+		},
+	}
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	p.PopulationType = current.Label
+	p.CancelURI = fmt.Sprintf("/filters/%s/dimensions", m.fid)
+
+	for _, populationType := range populationTypes {
+		p.Selections = append(p.Selections, model.Selection{
+			Value:       populationType.Name,
+			Label:       populationType.Label,
+			Description: populationType.Description,
+		})
+	}
+
+	if change == nil {
+		return p
+	}
+
+	p.HasReview = true
+	p.InitialSelection = change.PopulationType.Name
+	p.Selected = change.PopulationType.Label
+	p.AreaType = cleanDimensionLabel(change.AreaType.Label)
+	p.IsAreaTypeChanged = change.IsAreaTypeChanged
+	p.KeptVariables = []string{}
+	for _, dim := range change.Kept {
+		p.KeptVariables = append(p.KeptVariables, cleanDimensionLabel(dim.Label))
+	}
+	p.DroppedVariables = []string{}
+	for _, dim := range change.Dropped {
+		p.DroppedVariables = append(p.DroppedVariables, cleanDimensionLabel(dim.Label))
+	}
+	p.DroppedAreas = []string{}
+	for _, area := range change.DroppedAreas {
+		p.DroppedAreas = append(p.DroppedAreas, area.Label)
+	}
+
+	return p
+}
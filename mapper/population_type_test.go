@@ -0,0 +1,68 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreatePopulationTypeSelector(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	eb := getTestEmergencyBanner()
+	sm := getTestServiceMessage()
+	current := population.PopulationType{Name: "UR", Label: "All usual residents"}
+	populationTypes := []population.PopulationType{
+		{Name: "HH", Label: "All households", Description: "Households in England and Wales"},
+		{Name: "UR_CE", Label: "All usual residents in communal establishments"},
+	}
+
+	Convey("Given population types to choose from", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "en", sm, "12345")
+		p := m.CreatePopulationTypeSelector(current, populationTypes, nil)
+
+		Convey("Then it maps the page metadata", func() {
+			So(p.Metadata.Title, ShouldEqual, "Change population type")
+			So(p.Type, ShouldEqual, "filter-flex-population-type")
+			So(p.Breadcrumb[0].URI, ShouldEqual, "/filters/12345/dimensions")
+			So(p.CancelURI, ShouldEqual, "/filters/12345/dimensions")
+		})
+
+		Convey("Then it maps the current and the other population types", func() {
+			So(p.PopulationType, ShouldEqual, "All usual residents")
+			So(p.Selections, ShouldHaveLength, 2)
+			So(p.Selections[0].Value, ShouldEqual, "HH")
+			So(p.Selections[0].Label, ShouldEqual, "All households")
+			So(p.Selections[0].Description, ShouldEqual, "Households in England and Wales")
+			So(p.HasReview, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given a change of population type", t, func() {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, eb, "cy", sm, "12345")
+		p := m.CreatePopulationTypeSelector(current, populationTypes, &PopulationTypeChange{
+			PopulationType: populationTypes[0],
+			Kept:           []filter.Dimension{{ID: "sex", Label: "Sex (2 categories)"}},
+			Dropped:        []filter.Dimension{{ID: "resident_age_18b", Label: "Age (18 categories)"}},
+			AreaType:       population.AreaType{ID: "ltla", Label: "Lower tier local authorities"},
+			DroppedAreas:   []population.Area{{ID: "W06000015", Label: "Cardiff"}},
+		})
+
+		Convey("Then the page is localised and the change is reviewed", func() {
+			So(p.Metadata.Title, ShouldEqual, "Change population type (cy)")
+			So(p.HasReview, ShouldBeTrue)
+			So(p.InitialSelection, ShouldEqual, "HH")
+			So(p.Selected, ShouldEqual, "All households")
+			So(p.KeptVariables, ShouldResemble, []string{"Sex"})
+			So(p.DroppedVariables, ShouldResemble, []string{"Age"})
+			So(p.AreaType, ShouldEqual, "Lower tier local authorities")
+			So(p.IsAreaTypeChanged, ShouldBeFalse)
+			So(p.DroppedAreas, ShouldResemble, []string{"Cardiff"})
+		})
+	})
+}
@@ -0,0 +1,67 @@
+package mapper
+
+import (
+	"fmt"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/pagination"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// CreateSDCAreas maps data to the SDCAreas model
+func (m *Mapper) CreateSDCAreas(geogName string, sdc cantabular.GetBlockedAreaCountResult, areas []model.SDCArea, totalCount, currentPage, limit int) model.SDCAreas {
+	cfg, _ := config.Get()
+
+	p := model.SDCAreas{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, sdcAreasPageType, helper.Localise("SDCAreasTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.FilterID = m.fid
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	p.Breadcrumb = []coreModel.TaxonomyNode{
+		{
+			Title: helper.Localise("Back", m.lang, 1),
+			URI:   fmt.Sprintf("/filters/%s/dimensions", m.fid),
+		},
+	}
+
+	geography := helpers.Pluralise(m.req, geogName, m.lang, areaTypePrefix, pluralInt)
+	if geography == "" {
+		geography = geogName
+	}
+	p.Geography = geography
+	p.CoverageURI = fmt.Sprintf("/filters/%s/dimensions/geography/coverage", m.fid)
+	p.HasCoverage = totalCount > 0
+
+	if sdc.Blocked > 0 {
+		p.Panel = *m.mapBlockedAreasPanel(&sdc, false, model.Pending)
+	} else {
+		p.Panel = *m.mapBlockedAreasPanel(&sdc, false, model.Success)
+	}
+	// removing every option would reset the coverage, so only offer removal when some areas are available
+	p.ShowRemoveButton = sdc.Blocked > 0 && sdc.Passed > 0
+
+	for _, area := range areas {
+		switch {
+		case area.Total > 1:
+			area.Status = helper.Localise("SDCAreaPartial", m.lang, 1, helper.ThousandsSeparator(area.Passed), helper.ThousandsSeparator(area.Total))
+		case area.IsBlocked:
+			area.Status = helper.Localise("SDCAreaBlocked", m.lang, 1)
+		default:
+			area.Status = helper.Localise("SDCAreaAvailable", m.lang, 1)
+		}
+		p.Areas = append(p.Areas, area)
+	}
+
+	totalPages := pagination.GetTotalPages(totalCount, limit)
+	if totalPages > 1 {
+		p.Pagination = pagination.GetPagination(m.req, currentPage, totalPages, limit, m.lang)
+	}
+
+	return p
+}
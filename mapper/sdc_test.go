@@ -0,0 +1,85 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateSDCAreas(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("", "/filters/12345/sdc/areas", nil)
+	m := NewMapper(req, coreModel.Page{}, getTestEmergencyBanner(), "en", getTestServiceMessage(), "12345")
+	areas := []model.SDCArea{
+		{ID: "E1", Label: "Area 1", IsBlocked: true, Blocked: 1, Total: 1},
+		{ID: "E2", Label: "Area 2", Passed: 1, Total: 1},
+		{ID: "E3", Label: "Area 3", IsBlocked: true, Passed: 2, Blocked: 1, Total: 3},
+	}
+
+	Convey("Given some areas are blocked", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 3, Blocked: 2, Total: 5}
+		p := m.CreateSDCAreas("Area type", sdc, areas, 3, 1, 50)
+
+		Convey("Then the page maps common props", func() {
+			So(p.FilterID, ShouldEqual, "12345")
+			So(p.Type, ShouldEqual, sdcAreasPageType)
+			So(p.Breadcrumb[0].URI, ShouldEqual, "/filters/12345/dimensions")
+			So(p.CoverageURI, ShouldEqual, "/filters/12345/dimensions/geography/coverage")
+		})
+		Convey("Then the pending panel is displayed", func() {
+			So(p.Panel.Type, ShouldEqual, model.Pending)
+		})
+		Convey("Then the status of each area is mapped", func() {
+			So(p.Areas, ShouldHaveLength, 3)
+			So(p.Areas[0].Status, ShouldEqual, "Not available")
+			So(p.Areas[1].Status, ShouldEqual, "Available")
+			So(p.Areas[2].Status, ShouldEqual, "2 out of 3 areas available")
+		})
+		Convey("Then the remove button is displayed", func() {
+			So(p.HasCoverage, ShouldBeTrue)
+			So(p.ShowRemoveButton, ShouldBeTrue)
+		})
+		Convey("Then pagination is not required", func() {
+			So(p.Pagination.TotalPages, ShouldEqual, 0)
+		})
+	})
+
+	Convey("Given all areas are blocked", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 0, Blocked: 5, Total: 5}
+		p := m.CreateSDCAreas("Area type", sdc, areas, 3, 1, 50)
+
+		Convey("Then the remove button is not displayed", func() {
+			So(p.ShowRemoveButton, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given no coverage has been selected", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 5, Total: 5}
+		p := m.CreateSDCAreas("Area type", sdc, nil, 0, 1, 50)
+
+		Convey("Then the page has no coverage", func() {
+			So(p.HasCoverage, ShouldBeFalse)
+			So(p.Panel.Type, ShouldEqual, model.Success)
+		})
+	})
+
+	Convey("Given more areas than the page size", t, func() {
+		sdc := cantabular.GetBlockedAreaCountResult{Passed: 3, Blocked: 2, Total: 5}
+		p := m.CreateSDCAreas("Area type", sdc, areas[:2], 3, 2, 2)
+
+		Convey("Then the pagination is mapped", func() {
+			So(p.Pagination.TotalPages, ShouldEqual, 2)
+			So(p.Pagination.CurrentPage, ShouldEqual, 2)
+			So(p.Pagination.Limit, ShouldEqual, 2)
+			So(p.Pagination.Previous.URL, ShouldNotBeEmpty)
+			So(p.Pagination.RelLinks, ShouldHaveLength, 1)
+			So(p.Pagination.RelLinks[0].Rel, ShouldEqual, "prev")
+		})
+	})
+}
@@ -4,11 +4,14 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/welsh"
 	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 )
@@ -40,15 +43,20 @@ func (m *Mapper) CreateCategorisationsSelector(dimLabel, dimId string, cats popu
 	}
 	p.Selections = selections
 	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
 
 	isValidationError, _ := strconv.ParseBool(m.req.URL.Query().Get("error"))
 	if isValidationError {
+		errorKey := "SelectCategoriesError"
+		if isInvalid, _ := strconv.ParseBool(m.req.URL.Query().Get("invalid")); isInvalid {
+			errorKey = "SelectCategoriesInvalidError"
+		}
 		p.Page.Error = coreModel.Error{
 			Title: p.Page.Metadata.Title,
 			ErrorItems: []coreModel.ErrorItem{
 				{
 					Description: coreModel.Localisation{
-						LocaleKey: "SelectCategoriesError",
+						LocaleKey: errorKey,
 						Plural:    1,
 					},
 					URL: "#categories-error",
@@ -62,8 +70,16 @@ func (m *Mapper) CreateCategorisationsSelector(dimLabel, dimId string, cats popu
 	return p
 }
 
-// CreateAreaTypeSelector maps data to the Selector model
-func (m *Mapper) CreateAreaTypeSelector(areaType []population.AreaType, fDim filter.Dimension, lowest_geography, releaseDate string, dataset dataset.DatasetDetails, hasOpts bool) model.Selector {
+// AreaTypeDetails represents example areas of an area type, the area types which contain it
+// and the disclosure control outcome of the variables of the filter at the area type
+type AreaTypeDetails struct {
+	Examples []population.Area
+	Parents  []population.AreaType
+	SDC      *cantabular.GetBlockedAreaCountResult
+}
+
+// CreateAreaTypeSelector maps data to the Selector model, area types without details are shown without them
+func (m *Mapper) CreateAreaTypeSelector(areaType []population.AreaType, fDim filter.Dimension, lowest_geography, highest_geography, releaseDate string, dataset dataset.DatasetDetails, hasOpts bool, details map[string]AreaTypeDetails) model.Selector {
 	cfg, _ := config.Get()
 
 	p := model.Selector{
@@ -86,12 +102,16 @@ func (m *Mapper) CreateAreaTypeSelector(areaType []population.AreaType, fDim fil
 
 	isValidationError, _ := strconv.ParseBool(m.req.URL.Query().Get("error"))
 	if isValidationError {
+		errorKey := "SelectAreaTypeError"
+		if isInvalid, _ := strconv.ParseBool(m.req.URL.Query().Get("invalid")); isInvalid {
+			errorKey = "SelectAreaTypeInvalidError"
+		}
 		p.Page.Error = coreModel.Error{
 			Title: p.Page.Metadata.Title,
 			ErrorItems: []coreModel.ErrorItem{
 				{
 					Description: coreModel.Localisation{
-						LocaleKey: "SelectAreaTypeError",
+						LocaleKey: errorKey,
 						Plural:    1,
 					},
 					URL: "#area-type-error",
@@ -104,27 +124,55 @@ func (m *Mapper) CreateAreaTypeSelector(areaType []population.AreaType, fDim fil
 
 	selections := mapAreaTypesToSelection(sortAreaTypes(areaType))
 
-	if lowest_geography != "" {
-		var filtered_selections []model.Selection
-		var lowest_found = false
-		for _, selection := range selections {
-			if !lowest_found {
-				filtered_selections = append(filtered_selections, selection)
-				lowest_found = selection.Value == lowest_geography
+	ids := make([]string, 0, len(selections))
+	for _, selection := range selections {
+		ids = append(ids, selection.Value)
+	}
+	allowed := map[string]bool{}
+	for _, id := range geography.Range(ids, lowest_geography, highest_geography) {
+		allowed[id] = true
+	}
+	recommended := -1
+	for _, selection := range selections {
+		if !allowed[selection.Value] {
+			continue
+		}
+		detail := details[selection.Value]
+		for _, area := range detail.Examples {
+			selection.Examples = append(selection.Examples, welsh.Label(area.ID, area.Label, m.lang))
+		}
+		for _, parent := range sortAreaTypes(detail.Parents) {
+			selection.Parents = append(selection.Parents, parent.Label)
+		}
+		if sdc := detail.SDC; sdc != nil {
+			if sdc.TableError != "" {
+				selection.SDCText = helper.Localise("AreaTypeSDCUnavailable", m.lang, 1)
+			} else {
+				selection.SDCText = helper.Localise("AreaTypeSDCAvailable", m.lang, 1,
+					helper.ThousandsSeparator(sdc.Passed),
+					helper.ThousandsSeparator(sdc.Total))
+				// selections are ordered from the highest area type, so the last without blocked areas is the finest
+				if sdc.Blocked == 0 && sdc.Total > 0 {
+					recommended = len(p.Selections)
+				}
 			}
 		}
-		p.Selections = filtered_selections
-	} else {
-		p.Selections = selections
+		p.Selections = append(p.Selections, selection)
+	}
+	if recommended >= 0 {
+		p.Selections[recommended].IsRecommended = true
 	}
 
 	p.InitialSelection = fDim.ID
 	p.IsAreaType = true
 
-	p.DatasetId = dataset.ID
-	p.DatasetTitle = dataset.Title
-	p.ReleaseDate = releaseDate
 	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	if p.DatasetTitle == "" {
+		p.DatasetId = dataset.ID
+		p.DatasetTitle = dataset.Title
+		p.ReleaseDate = releaseDate
+	}
 
 	return p
 }
@@ -4,6 +4,7 @@ import (
 	"net/http/httptest"
 	"testing"
 
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
 	"github.com/ONSdigital/dp-api-clients-go/v2/dataset"
 	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
@@ -171,6 +172,13 @@ func TestCreateCategorisationsSelector(t *testing.T) {
 				So(selector.ErrorId, ShouldEqual, "categories-error")
 			})
 		})
+		Convey("When the categorisation is not offered by the selector", func() {
+			m.req = httptest.NewRequest("", "/?error=true&invalid=true", nil)
+			selector := m.CreateCategorisationsSelector("Dimension", "dim1234", population.GetCategorisationsResponse{})
+			Convey("Then it populates the invalid categorisation error", func() {
+				So(selector.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "SelectCategoriesInvalidError")
+			})
+		})
 
 		Convey("When categories are greater than 9", func() {
 			cats := population.GetCategorisationsResponse{
@@ -302,7 +310,7 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 			{ID: "two", Label: "Two", Description: "Two description", TotalCount: 2},
 		}
 
-		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		expectedSelections := []model.Selection{
 			{Value: "one", Label: "One", Description: "One description", TotalCount: 1},
@@ -314,6 +322,61 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 		})
 	})
 
+	Convey("Given the disclosure control outcome at the area types", t, func() {
+		areas := []population.AreaType{
+			{ID: "ctry", Label: "Countries", Hierarchy_Order: 900},
+			{ID: "rgn", Label: "Regions", Hierarchy_Order: 800},
+			{ID: "ltla", Label: "Lower tier local authorities", Hierarchy_Order: 500},
+			{ID: "oa", Label: "Output areas", Hierarchy_Order: 100},
+		}
+		details := map[string]AreaTypeDetails{
+			"ctry": {SDC: &cantabular.GetBlockedAreaCountResult{Passed: 2, Total: 2}},
+			"rgn":  {SDC: &cantabular.GetBlockedAreaCountResult{Passed: 10, Total: 10}},
+			"ltla": {SDC: &cantabular.GetBlockedAreaCountResult{Passed: 300, Blocked: 31, Total: 331}},
+			"oa":   {SDC: &cantabular.GetBlockedAreaCountResult{TableError: "max cells exceeded"}},
+		}
+
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, details)
+
+		Convey("Then the areas available are mapped against each area type", func() {
+			So(changeDimension.Selections[0].SDCText, ShouldEqual, "2 out of 2 areas available")
+			So(changeDimension.Selections[2].SDCText, ShouldEqual, "300 out of 331 areas available")
+			So(changeDimension.Selections[3].SDCText, ShouldEqual, "Too many combinations to check")
+		})
+
+		Convey("Then only the finest area type without blocked areas is recommended", func() {
+			So(changeDimension.Selections[0].IsRecommended, ShouldBeFalse)
+			So(changeDimension.Selections[1].IsRecommended, ShouldBeTrue)
+			So(changeDimension.Selections[2].IsRecommended, ShouldBeFalse)
+			So(changeDimension.Selections[3].IsRecommended, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given details of the area types", t, func() {
+		areas := []population.AreaType{
+			{ID: "ltla", Label: "Lower tier local authorities", Hierarchy_Order: 500},
+			{ID: "msoa", Label: "MSOA", Hierarchy_Order: 300},
+		}
+		details := map[string]AreaTypeDetails{
+			"ltla": {
+				Examples: []population.Area{{ID: "E06000001", Label: "Hartlepool"}, {ID: "E06000002", Label: "Middlesbrough"}},
+				Parents:  []population.AreaType{{ID: "rgn", Label: "Regions", Hierarchy_Order: 800}, {ID: "ctry", Label: "Countries", Hierarchy_Order: 900}},
+			},
+		}
+
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, details)
+
+		Convey("Then the example areas and parent area types are mapped from the highest parent", func() {
+			So(changeDimension.Selections[0].Examples, ShouldResemble, []string{"Hartlepool", "Middlesbrough"})
+			So(changeDimension.Selections[0].Parents, ShouldResemble, []string{"Countries", "Regions"})
+		})
+
+		Convey("Then area types without details are mapped without them", func() {
+			So(changeDimension.Selections[1].Examples, ShouldBeNil)
+			So(changeDimension.Selections[1].Parents, ShouldBeNil)
+		})
+	})
+
 	Convey("Given a slice of geography areas", t, func() {
 		areas := []population.AreaType{
 			{ID: "nat", Label: "Nation", TotalCount: 1},
@@ -322,7 +385,7 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 			{ID: "utla", Label: "UTLA", TotalCount: 4},
 		}
 
-		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		expectedSelections := []model.Selection{
 			{Value: "nat", Label: "Nation", TotalCount: 1},
@@ -344,7 +407,7 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 			{ID: "utla", Label: "UTLA", TotalCount: 7, Hierarchy_Order: 700},
 		}
 
-		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		Convey("Sorts selections ascending by standard order", func() {
 			expectedSelections := []model.Selection{
@@ -367,7 +430,7 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 		}
 		lowest_geography := "rgn"
 
-		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, lowest_geography, "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, lowest_geography, "", "", dataset.DatasetDetails{}, false, nil)
 
 		Convey("Returns the sorted selections stopping at the lowest_level", func() {
 			expectedSelections := []model.Selection{
@@ -380,8 +443,28 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 		})
 	})
 
+	Convey("Given an unsorted slice of geography areas and lowest and highest levels of geography", t, func() {
+		areas := []population.AreaType{
+			{ID: "rgn", Label: "Region", TotalCount: 11, Hierarchy_Order: 800},
+			{ID: "ctry", Label: "Country", TotalCount: 33, Hierarchy_Order: 900},
+			{ID: "nat", Label: "Nation", TotalCount: 1, Hierarchy_Order: 1000},
+			{ID: "utla", Label: "UTLA", TotalCount: 7, Hierarchy_Order: 700},
+		}
+
+		changeDimension := m.CreateAreaTypeSelector(areas, filter.Dimension{}, "rgn", "ctry", "", dataset.DatasetDetails{}, false, nil)
+
+		Convey("Returns the sorted selections from the highest_level to the lowest_level", func() {
+			expectedSelections := []model.Selection{
+				{Value: "ctry", Label: "Country", TotalCount: 33},
+				{Value: "rgn", Label: "Region", TotalCount: 11},
+			}
+
+			So(changeDimension.Selections, ShouldResemble, expectedSelections)
+		})
+	})
+
 	Convey("Given a valid page", t, func() {
-		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		Convey("it sets page metadata", func() {
 			So(changeDimension.BetaBannerEnabled, ShouldBeTrue)
@@ -412,7 +495,7 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 
 	Convey("Given the current filter dimension", t, func() {
 		const selectionName = "test"
-		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{ID: selectionName}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{ID: selectionName}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		Convey("it returns the value as an initial selection", func() {
 			So(changeDimension.InitialSelection, ShouldEqual, selectionName)
@@ -421,15 +504,24 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 
 	Convey("Given a validation error", t, func() {
 		m.req = httptest.NewRequest("", "/?error=true", nil)
-		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", dataset.DatasetDetails{}, false)
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
 
 		Convey("it returns a populated error", func() {
 			So(changeDimension.Error.Title, ShouldNotBeEmpty)
 		})
 	})
 
+	Convey("Given an area type which is not offered by the selector", t, func() {
+		m.req = httptest.NewRequest("", "/?error=true&invalid=true", nil)
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, false, nil)
+
+		Convey("it returns the invalid area type error", func() {
+			So(changeDimension.Error.ErrorItems[0].Description.LocaleKey, ShouldEqual, "SelectAreaTypeInvalidError")
+		})
+	})
+
 	Convey("Given saved options", t, func() {
-		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", dataset.DatasetDetails{}, true)
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, true, nil)
 
 		Convey("it maps a warning that saved options will be removed", func() {
 			So(changeDimension.Panel.Body, ShouldEqual, "Saved options warning")
@@ -438,15 +530,40 @@ func TestCreateAreaTypeSelector(t *testing.T) {
 		})
 	})
 
-	Convey("Given analytics metadata", t, func() {
-		releaseDate := "2022/11/29"
-		dataset := dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"}
-		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", releaseDate, dataset, true)
+	Convey("Given the dataset context of the filter", t, func() {
+		m := NewMapper(req, coreModel.Page{}, eb, "en", sm, "12345")
+		m.SetDatasetContext(DatasetContext{
+			Dataset:     filter.Dataset{DatasetID: "dataset-id", Edition: "2021", Version: 1},
+			Title:       "Dataset title",
+			ReleaseDate: "2022/11/29",
+		})
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "", dataset.DatasetDetails{}, true, nil)
 
 		Convey("it sets DatasetID, DatasetTitle and ReleaseData", func() {
-			So(changeDimension.DatasetId, ShouldEqual, dataset.ID)
-			So(changeDimension.DatasetTitle, ShouldEqual, dataset.Title)
-			So(changeDimension.ReleaseDate, ShouldEqual, releaseDate)
+			So(changeDimension.DatasetId, ShouldEqual, "dataset-id")
+			So(changeDimension.DatasetTitle, ShouldEqual, "Dataset title")
+			So(changeDimension.ReleaseDate, ShouldEqual, "2022/11/29")
+		})
+
+		Convey("it maps the dataset context", func() {
+			So(changeDimension.DatasetContext.Title, ShouldEqual, "Dataset title")
+			So(changeDimension.DatasetContext.URI, ShouldEqual, "/datasets/dataset-id/editions/2021/versions/1")
+		})
+	})
+
+	Convey("Given the dataset context has no title", t, func() {
+		m := NewMapper(req, coreModel.Page{}, eb, "en", sm, "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: filter.Dataset{DatasetID: "dataset-id"}})
+		changeDimension := m.CreateAreaTypeSelector(nil, filter.Dimension{}, "", "", "2022/11/29", dataset.DatasetDetails{ID: "dataset-id", Title: "Dataset title"}, true, nil)
+
+		Convey("it falls back to the dataset for DatasetID, DatasetTitle and ReleaseData", func() {
+			So(changeDimension.DatasetId, ShouldEqual, "dataset-id")
+			So(changeDimension.DatasetTitle, ShouldEqual, "Dataset title")
+			So(changeDimension.ReleaseDate, ShouldEqual, "2022/11/29")
+		})
+
+		Convey("it does not map the dataset context", func() {
+			So(changeDimension.DatasetContext, ShouldResemble, model.DatasetContext{})
 		})
 	})
 }
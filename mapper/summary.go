@@ -0,0 +1,127 @@
+package mapper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// CreateFilterSummary maps data to the FilterSummary model, every area and category is listed without truncation
+func (m *Mapper) CreateFilterSummary(filterJob filter.GetFilterResponse, filterDims []model.FilterDimension, areas []population.Area, categories map[string][]population.DimensionCategoryItem, pops population.GetPopulationTypeResponse, sdc cantabular.GetBlockedAreaCountResult, isMultivariate bool) model.FilterSummary {
+	cfg, _ := config.Get()
+
+	p := model.FilterSummary{
+		Page: m.basePage,
+	}
+	mapCommonProps(m.req, &p.Page, "filter-flex-summary", helper.Localise("SummaryTitle", m.lang, 1), m.lang, m.serviceMsg, m.eb)
+	p.Breadcrumb = []coreModel.TaxonomyNode{
+		{
+			Title: helper.Localise("Back", m.lang, 1),
+			URI:   fmt.Sprintf("/filters/%s/dimensions", m.fid),
+		},
+	}
+	p.FeatureFlags.FeedbackAPIURL = cfg.FeedbackAPIURL
+	p.DatasetContext = m.mapDatasetContext(&p.Page)
+	p.FilterID = filterJob.FilterID
+	p.OverviewURI = fmt.Sprintf("/filters/%s/dimensions", m.fid)
+	p.CSVURI = fmt.Sprintf("/filters/%s/summary.csv", m.fid)
+	p.PopulationType = model.SummaryItem{
+		Code:  pops.PopulationType.Name,
+		Label: pops.PopulationType.Label,
+	}
+
+	p.Coverage = []model.SummaryItem{}
+	for _, area := range areas {
+		p.Coverage = append(p.Coverage, model.SummaryItem{
+			Code:  area.ID,
+			Label: area.Label,
+		})
+	}
+	if len(p.Coverage) == 0 {
+		p.DefaultCoverage = helper.Localise("AreaTypeDefaultCoverage", m.lang, 1)
+	}
+
+	p.Variables = []model.SummaryVariable{}
+	for _, dim := range filterDims {
+		if helpers.IsBoolPtr(dim.IsAreaType) {
+			p.AreaType = model.SummaryItem{
+				Code:  dim.ID,
+				Label: dim.Label,
+			}
+			continue
+		}
+
+		variable := model.SummaryVariable{
+			Code:       dim.ID,
+			Label:      dim.Label,
+			Categories: []model.SummaryItem{},
+		}
+		for _, category := range categories[dim.Name] {
+			variable.Categories = append(variable.Categories, model.SummaryItem{
+				Code:  category.ID,
+				Label: category.Label,
+			})
+		}
+		p.Variables = append(p.Variables, variable)
+	}
+
+	if isMultivariate {
+		p.HasSDC = true
+		p.SDC = model.SummarySDC{
+			Passed:  sdc.Passed,
+			Blocked: sdc.Blocked,
+			Total:   sdc.Total,
+		}
+	}
+
+	return p
+}
+
+// CreateFilterSummaryCSV maps the FilterSummary model to the rows of a csv file, with a header row
+func (m *Mapper) CreateFilterSummaryCSV(p model.FilterSummary) [][]string {
+	localise := func(key string) string {
+		return helper.Localise(key, m.lang, 1)
+	}
+
+	rows := [][]string{
+		{localise("SummaryItem"), localise("SummaryVariable"), localise("SummaryCode"), localise("SummaryLabel")},
+		{localise("SummaryDataset"), "", m.dataset.Dataset.DatasetID, p.DatasetContext.Title},
+		{localise("SummaryEdition"), "", "", p.DatasetContext.Edition},
+		{localise("SummaryVersion"), "", "", p.DatasetContext.Version},
+		{localise("SummaryReleaseDate"), "", "", p.DatasetContext.ReleaseDate},
+		{localise("SummaryPopulationType"), "", p.PopulationType.Code, p.PopulationType.Label},
+		{localise("SummaryAreaType"), "", p.AreaType.Code, p.AreaType.Label},
+	}
+
+	for _, area := range p.Coverage {
+		rows = append(rows, []string{localise("SummaryCoverage"), p.AreaType.Code, area.Code, area.Label})
+	}
+	if p.DefaultCoverage != "" {
+		rows = append(rows, []string{localise("SummaryCoverage"), p.AreaType.Code, "", p.DefaultCoverage})
+	}
+
+	for _, variable := range p.Variables {
+		rows = append(rows, []string{localise("SummaryVariable"), "", variable.Code, variable.Label})
+		for _, category := range variable.Categories {
+			rows = append(rows, []string{localise("SummaryCategory"), variable.Code, category.Code, category.Label})
+		}
+	}
+
+	if p.HasSDC {
+		rows = append(rows,
+			[]string{localise("SummaryAvailableAreas"), "", "", strconv.Itoa(p.SDC.Passed)},
+			[]string{localise("SummaryBlockedAreas"), "", "", strconv.Itoa(p.SDC.Blocked)},
+			[]string{localise("SummaryTotalAreas"), "", "", strconv.Itoa(p.SDC.Total)},
+		)
+	}
+
+	return rows
+}
@@ -0,0 +1,99 @@
+package mapper
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCreateFilterSummary(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	filterJob := filter.GetFilterResponse{
+		FilterID:       "12345",
+		PopulationType: "UR",
+		Dataset:        filter.Dataset{DatasetID: "example", Edition: "2021", Version: 1},
+	}
+	filterDims := []model.FilterDimension{
+		{Dimension: filter.Dimension{Name: "ltla", ID: "ltla", Label: "Lower tier local authorities", IsAreaType: helpers.ToBoolPtr(true)}},
+		{Dimension: filter.Dimension{Name: "sex", ID: "sex_2a", Label: "Sex (2 categories)", IsAreaType: helpers.ToBoolPtr(false)}},
+		{Dimension: filter.Dimension{Name: "age", ID: "resident_age_3a", Label: "Age (3 categories)", IsAreaType: helpers.ToBoolPtr(false)}},
+	}
+	categories := map[string][]population.DimensionCategoryItem{
+		"sex": {{ID: "1", Label: "Female"}, {ID: "2", Label: "Male"}},
+		"age": {{ID: "1", Label: "Children"}, {ID: "2", Label: "Adults 16 to 64"}, {ID: "3", Label: "Adults 65 and over"}},
+	}
+	pop := population.GetPopulationTypeResponse{PopulationType: population.PopulationType{Name: "UR", Label: "All usual residents"}}
+	sdc := cantabular.GetBlockedAreaCountResult{Passed: 2, Blocked: 1, Total: 3}
+
+	newMapper := func(lang string) *Mapper {
+		m := NewMapper(httptest.NewRequest("", "/", nil), coreModel.Page{}, getTestEmergencyBanner(), lang, getTestServiceMessage(), "12345")
+		m.SetDatasetContext(DatasetContext{Dataset: filterJob.Dataset, Title: "Dataset title", ReleaseDate: "2022-11-29T09:30:00.000Z"})
+		return m
+	}
+
+	Convey("Given a filter of a multivariate dataset with selected areas", t, func() {
+		m := newMapper("en")
+		areas := []population.Area{{ID: "E06000001", Label: "Hartlepool"}}
+		p := m.CreateFilterSummary(filterJob, filterDims, areas, categories, pop, sdc, true)
+
+		Convey("Then it maps the page metadata", func() {
+			So(p.Metadata.Title, ShouldEqual, "Summary of your filter")
+			So(p.Type, ShouldEqual, "filter-flex-summary")
+			So(p.OverviewURI, ShouldEqual, "/filters/12345/dimensions")
+			So(p.CSVURI, ShouldEqual, "/filters/12345/summary.csv")
+			So(p.DatasetContext.Title, ShouldEqual, "Dataset title")
+		})
+
+		Convey("Then it maps the area type, coverage and every category of each variable", func() {
+			So(p.PopulationType, ShouldResemble, model.SummaryItem{Code: "UR", Label: "All usual residents"})
+			So(p.AreaType, ShouldResemble, model.SummaryItem{Code: "ltla", Label: "Lower tier local authorities"})
+			So(p.Coverage, ShouldResemble, []model.SummaryItem{{Code: "E06000001", Label: "Hartlepool"}})
+			So(p.DefaultCoverage, ShouldBeEmpty)
+			So(p.Variables, ShouldHaveLength, 2)
+			So(p.Variables[0].Categories, ShouldHaveLength, 2)
+			So(p.Variables[1].Categories, ShouldHaveLength, 3)
+		})
+
+		Convey("Then it maps the disclosure control result", func() {
+			So(p.HasSDC, ShouldBeTrue)
+			So(p.SDC, ShouldResemble, model.SummarySDC{Passed: 2, Blocked: 1, Total: 3})
+		})
+
+		Convey("Then the csv rows list the same data", func() {
+			rows := m.CreateFilterSummaryCSV(p)
+			So(rows[0], ShouldResemble, []string{"Item", "Variable", "Code", "Label"})
+			So(rows[1], ShouldResemble, []string{"Dataset", "", "example", "Dataset title"})
+			So(rows, ShouldContain, []string{"Coverage", "ltla", "E06000001", "Hartlepool"})
+			So(rows, ShouldContain, []string{"Category", "sex_2a", "2", "Male"})
+			So(rows, ShouldContain, []string{"Category", "resident_age_3a", "3", "Adults 65 and over"})
+			So(rows[len(rows)-2], ShouldResemble, []string{"Blocked areas", "", "", "1"})
+		})
+	})
+
+	Convey("Given a filter without selected areas", t, func() {
+		m := newMapper("cy")
+		p := m.CreateFilterSummary(filterJob, filterDims, nil, categories, pop, sdc, false)
+
+		Convey("Then the default coverage is mapped and localised", func() {
+			So(p.Metadata.Title, ShouldEqual, "Summary of your filter (cy)")
+			So(p.Coverage, ShouldBeEmpty)
+			So(p.DefaultCoverage, ShouldNotBeEmpty)
+			So(p.HasSDC, ShouldBeFalse)
+		})
+
+		Convey("Then the csv rows have the default coverage and no disclosure control result", func() {
+			rows := m.CreateFilterSummaryCSV(p)
+			So(rows, ShouldContain, []string{"Coverage (cy)", "ltla", "", p.DefaultCoverage})
+			So(rows[len(rows)-1][0], ShouldEqual, "Category (cy)")
+		})
+	})
+}
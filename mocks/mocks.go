@@ -39,6 +39,143 @@ var cyLocale = []string{
 	"one = \"Improve your results (cy)\"",
 	"[ImproveResultsListVariant]",
 	"one = \"Improve your results variant (cy)\"",
+	"[SuggestionsTitle]",
+	"one = \"Suggested changes (cy)\"",
+	"[SuggestionsLeadText]",
+	"one = \"Suggestions lead text (cy)\"",
+	"[Suggestion]",
+	"one = \"Switch {{.arg0}} to {{.arg1}} to unlock {{.arg2}} more area (cy)\"",
+	"other = \"Switch {{.arg0}} to {{.arg1}} to unlock {{.arg2}} more areas (cy)\"",
+	"[SuggestionAreaType]",
+	"one = \"Switch {{.arg0}} to {{.arg1}} where {{.arg2}} of {{.arg3}} area is available (cy)\"",
+	"other = \"Switch {{.arg0}} to {{.arg1}} where {{.arg2}} of {{.arg3}} areas are available (cy)\"",
+	"[SuggestionApply]",
+	"one = \"Apply this change (cy)\"",
+	"[SDCAreasTitle]",
+	"one = \"Areas in your coverage (cy)\"",
+	"[SDCAreasLink]",
+	"one = \"See which areas are not available (cy)\"",
+	"[SDCAreaStatus]",
+	"one = \"Status (cy)\"",
+	"[SDCAreaAvailable]",
+	"one = \"Available (cy)\"",
+	"[SDCAreaBlocked]",
+	"one = \"Not available (cy)\"",
+	"[SDCAreasRemoveBlocked]",
+	"one = \"Remove areas that are not available from coverage (cy)\"",
+	"[SDCAreasNoCoverage]",
+	"one = \"You have not selected any areas. Select areas to see which of them are available. (cy)\"",
+	"[SDCAreasChangeCoverage]",
+	"one = \"Change coverage (cy)\"",
+	"[SDCAreaPartial]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available (cy)\"",
+	"[RecentFiltersTitle]",
+	"one = \"Recent filters (cy)\"",
+	"[RecentFiltersEmpty]",
+	"one = \"You have not created or changed any filters yet. (cy)\"",
+	"[RecentFiltersExpired]",
+	"one = \"Expired (cy)\"",
+	"[RecentFiltersSubmitted]",
+	"one = \"Submitted (cy)\"",
+	"[RecentFiltersUnknownDataset]",
+	"one = \"Unknown dataset (cy)\"",
+	"[RecentFiltersLastChanged]",
+	"one = \"You last changed this filter on {{.arg0}} (cy)\"",
+	"[CompareTitle]",
+	"one = \"Compare filters (cy)\"",
+	"[CompareFilterA]",
+	"one = \"Filter A (cy)\"",
+	"[CompareFilterB]",
+	"one = \"Filter B (cy)\"",
+	"[CompareStatus]",
+	"one = \"Status (cy)\"",
+	"[ComparePopulationType]",
+	"one = \"Population type (cy)\"",
+	"[CompareStatusAdded]",
+	"one = \"Added (cy)\"",
+	"[CompareStatusRemoved]",
+	"one = \"Removed (cy)\"",
+	"[CompareStatusChanged]",
+	"one = \"Changed (cy)\"",
+	"[CompareStatusCategorisation]",
+	"one = \"Different categorisation (cy)\"",
+	"[CompareStatusSame]",
+	"one = \"No change (cy)\"",
+	"[CompareNoDifferences]",
+	"one = \"These filters are the same. (cy)\"",
+	"[CompareSDC]",
+	"one = \"Areas available (cy)\"",
+	"[CompareSDCResult]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available (cy)\"",
+	"[CompareSDCNotApplicable]",
+	"one = \"Not applicable (cy)\"",
+	"[CoverageBrowseParentCount]",
+	"one = \"Includes {{.arg0}} area (cy)\"",
+	"other = \"Includes {{.arg0}} areas (cy)\"",
+	"[CoverageSelectAllMatching]",
+	"one = \"Add all {{.arg0}} matching results (cy)\"",
+	"[CoverageSelectAllLimit]",
+	"one = \"Up to {{.arg0}} results can be added at once (cy)\"",
+	"[CoverageSelectAllLimitError]",
+	"one = \"Too many results, narrow down to {{.arg0}} or fewer (cy)\"",
+	"[PaginationSummary]",
+	"one = \"Page {{.arg0}} of {{.arg1}} (cy)\"",
+	"[PaginationLabel]",
+	"one = \"Pagination ({{.arg0}}) (cy)\"",
+	"[PaginationPreviousLabel]",
+	"one = \"Go to the previous page (Page {{.arg0}}) (cy)\"",
+	"[PaginationNextLabel]",
+	"one = \"Go to the next page (Page {{.arg0}}) (cy)\"",
+	"[PaginationCurrentLabel]",
+	"one = \"Current page ({{.arg0}}) (cy)\"",
+	"[PaginationPrevious]",
+	"one = \"Previous (cy)\"",
+	"[PaginationNext]",
+	"one = \"Next (cy)\"",
+	"[DimensionsTopicOther]",
+	"one = \"Other variables (cy)\"",
+	"[SelectCategorySubsetLeadText]",
+	"one = \"Select the categories to include (cy)\"",
+	"[AreaTypeReviewTitle]",
+	"one = \"Review your coverage (cy)\"",
+	"[AreaTypeSDCAvailable]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available (cy)\"",
+	"[AreaTypeSDCUnavailable]",
+	"one = \"Too many combinations to check (cy)\"",
+	"[PopulationTypeTitle]",
+	"one = \"Change population type (cy)\"",
+	"[SummaryTitle]",
+	"one = \"Summary of your filter (cy)\"",
+	"[SummaryItem]",
+	"one = \"Item (cy)\"",
+	"[SummaryVariable]",
+	"one = \"Variable (cy)\"",
+	"[SummaryCode]",
+	"one = \"Code (cy)\"",
+	"[SummaryLabel]",
+	"one = \"Label (cy)\"",
+	"[SummaryDataset]",
+	"one = \"Dataset (cy)\"",
+	"[SummaryEdition]",
+	"one = \"Edition (cy)\"",
+	"[SummaryVersion]",
+	"one = \"Version (cy)\"",
+	"[SummaryReleaseDate]",
+	"one = \"Release date (cy)\"",
+	"[SummaryPopulationType]",
+	"one = \"Population type (cy)\"",
+	"[SummaryAreaType]",
+	"one = \"Area type (cy)\"",
+	"[SummaryCoverage]",
+	"one = \"Coverage (cy)\"",
+	"[SummaryCategory]",
+	"one = \"Category (cy)\"",
+	"[SummaryAvailableAreas]",
+	"one = \"Available areas (cy)\"",
+	"[SummaryBlockedAreas]",
+	"one = \"Blocked areas (cy)\"",
+	"[SummaryTotalAreas]",
+	"one = \"Total areas (cy)\"",
 	"[SDCAreasAvailable]",
 	"one = \"15 of 25 areas are available (cy)\"",
 	"[SDCRestrictedAreas]",
@@ -104,6 +241,143 @@ var enLocale = []string{
 	"one = \"Improve your results\"",
 	"[ImproveResultsListVariant]",
 	"one = \"Improve your results variant\"",
+	"[SuggestionsTitle]",
+	"one = \"Suggested changes\"",
+	"[SuggestionsLeadText]",
+	"one = \"Suggestions lead text\"",
+	"[Suggestion]",
+	"one = \"Switch {{.arg0}} to {{.arg1}} to unlock {{.arg2}} more area\"",
+	"other = \"Switch {{.arg0}} to {{.arg1}} to unlock {{.arg2}} more areas\"",
+	"[SuggestionAreaType]",
+	"one = \"Switch {{.arg0}} to {{.arg1}} where {{.arg2}} of {{.arg3}} area is available\"",
+	"other = \"Switch {{.arg0}} to {{.arg1}} where {{.arg2}} of {{.arg3}} areas are available\"",
+	"[SuggestionApply]",
+	"one = \"Apply this change\"",
+	"[SDCAreasTitle]",
+	"one = \"Areas in your coverage\"",
+	"[SDCAreasLink]",
+	"one = \"See which areas are not available\"",
+	"[SDCAreaStatus]",
+	"one = \"Status\"",
+	"[SDCAreaAvailable]",
+	"one = \"Available\"",
+	"[SDCAreaBlocked]",
+	"one = \"Not available\"",
+	"[SDCAreasRemoveBlocked]",
+	"one = \"Remove areas that are not available from coverage\"",
+	"[SDCAreasNoCoverage]",
+	"one = \"You have not selected any areas. Select areas to see which of them are available.\"",
+	"[SDCAreasChangeCoverage]",
+	"one = \"Change coverage\"",
+	"[SDCAreaPartial]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available\"",
+	"[RecentFiltersTitle]",
+	"one = \"Recent filters\"",
+	"[RecentFiltersEmpty]",
+	"one = \"You have not created or changed any filters yet.\"",
+	"[RecentFiltersExpired]",
+	"one = \"Expired\"",
+	"[RecentFiltersSubmitted]",
+	"one = \"Submitted\"",
+	"[RecentFiltersUnknownDataset]",
+	"one = \"Unknown dataset\"",
+	"[RecentFiltersLastChanged]",
+	"one = \"You last changed this filter on {{.arg0}}\"",
+	"[CompareTitle]",
+	"one = \"Compare filters\"",
+	"[CompareFilterA]",
+	"one = \"Filter A\"",
+	"[CompareFilterB]",
+	"one = \"Filter B\"",
+	"[CompareStatus]",
+	"one = \"Status\"",
+	"[ComparePopulationType]",
+	"one = \"Population type\"",
+	"[CompareStatusAdded]",
+	"one = \"Added\"",
+	"[CompareStatusRemoved]",
+	"one = \"Removed\"",
+	"[CompareStatusChanged]",
+	"one = \"Changed\"",
+	"[CompareStatusCategorisation]",
+	"one = \"Different categorisation\"",
+	"[CompareStatusSame]",
+	"one = \"No change\"",
+	"[CompareNoDifferences]",
+	"one = \"These filters are the same.\"",
+	"[CompareSDC]",
+	"one = \"Areas available\"",
+	"[CompareSDCResult]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available\"",
+	"[CompareSDCNotApplicable]",
+	"one = \"Not applicable\"",
+	"[CoverageBrowseParentCount]",
+	"one = \"Includes {{.arg0}} area\"",
+	"other = \"Includes {{.arg0}} areas\"",
+	"[CoverageSelectAllMatching]",
+	"one = \"Add all {{.arg0}} matching results\"",
+	"[CoverageSelectAllLimit]",
+	"one = \"Up to {{.arg0}} results can be added at once\"",
+	"[CoverageSelectAllLimitError]",
+	"one = \"Too many results, narrow down to {{.arg0}} or fewer\"",
+	"[PaginationSummary]",
+	"one = \"Page {{.arg0}} of {{.arg1}}\"",
+	"[PaginationLabel]",
+	"one = \"Pagination ({{.arg0}})\"",
+	"[PaginationPreviousLabel]",
+	"one = \"Go to the previous page (Page {{.arg0}})\"",
+	"[PaginationNextLabel]",
+	"one = \"Go to the next page (Page {{.arg0}})\"",
+	"[PaginationCurrentLabel]",
+	"one = \"Current page ({{.arg0}})\"",
+	"[PaginationPrevious]",
+	"one = \"Previous\"",
+	"[PaginationNext]",
+	"one = \"Next\"",
+	"[DimensionsTopicOther]",
+	"one = \"Other variables\"",
+	"[SelectCategorySubsetLeadText]",
+	"one = \"Select the categories to include\"",
+	"[AreaTypeReviewTitle]",
+	"one = \"Review your coverage\"",
+	"[AreaTypeSDCAvailable]",
+	"one = \"{{.arg0}} out of {{.arg1}} areas available\"",
+	"[AreaTypeSDCUnavailable]",
+	"one = \"Too many combinations to check\"",
+	"[PopulationTypeTitle]",
+	"one = \"Change population type\"",
+	"[SummaryTitle]",
+	"one = \"Summary of your filter\"",
+	"[SummaryItem]",
+	"one = \"Item\"",
+	"[SummaryVariable]",
+	"one = \"Variable\"",
+	"[SummaryCode]",
+	"one = \"Code\"",
+	"[SummaryLabel]",
+	"one = \"Label\"",
+	"[SummaryDataset]",
+	"one = \"Dataset\"",
+	"[SummaryEdition]",
+	"one = \"Edition\"",
+	"[SummaryVersion]",
+	"one = \"Version\"",
+	"[SummaryReleaseDate]",
+	"one = \"Release date\"",
+	"[SummaryPopulationType]",
+	"one = \"Population type\"",
+	"[SummaryAreaType]",
+	"one = \"Area type\"",
+	"[SummaryCoverage]",
+	"one = \"Coverage\"",
+	"[SummaryCategory]",
+	"one = \"Category\"",
+	"[SummaryAvailableAreas]",
+	"one = \"Available areas\"",
+	"[SummaryBlockedAreas]",
+	"one = \"Blocked areas\"",
+	"[SummaryTotalAreas]",
+	"one = \"Total areas\"",
 	"[SDCAreasAvailable]",
 	"one = \"15 of 25 areas are available\"",
 	"[SDCRestrictedAreas]",
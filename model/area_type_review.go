@@ -0,0 +1,15 @@
+package model
+
+import coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+
+// AreaTypeReview represents page data for reviewing the coverage kept when the area type changes
+type AreaTypeReview struct {
+	coreModel.Page
+	AreaType       string         `json:"area_type"`
+	Dimension      string         `json:"dimension"`
+	KeptAreas      []string       `json:"kept_areas"`
+	LostAreas      []string       `json:"lost_areas"`
+	IsParent       bool           `json:"is_parent"`
+	CancelURI      string         `json:"cancel_uri"`
+	DatasetContext DatasetContext `json:"dataset_context"`
+}
@@ -0,0 +1,14 @@
+package model
+
+import coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+
+// CategorySelector represents page data for choosing a subset of the categories of a variable
+type CategorySelector struct {
+	coreModel.Page
+	LeadText       string              `json:"lead_text"`
+	Categories     []SelectableElement `json:"categories"`
+	IsSubset       bool                `json:"is_subset"`
+	ErrorId        string              `json:"error_id"`
+	FeedbackAPIURL string              `json:"feedback_api_url"`
+	DatasetContext DatasetContext      `json:"dataset_context"`
+}
@@ -13,4 +13,26 @@ type ChangeDimensions struct {
 	HasSDC           bool         `json:"has_sdc"`
 	MaxVariableError bool         `json:"max_variable_error"`
 	ImproveResults   coreModel.Collapsible
+	Topics           []TopicGroup   `json:"topics"`
+	TopicOptions     []TopicOption  `json:"topic_options"`
+	SelectedTopic    string         `json:"selected_topic"`
+	DatasetContext   DatasetContext `json:"dataset_context"`
+}
+
+// TopicGroup represents the variables of a topic, displayed as a collapsible group
+type TopicGroup struct {
+	ID       string              `json:"id"`
+	Label    string              `json:"label"`
+	Count    int                 `json:"count"`
+	IsOpen   bool                `json:"is_open"`
+	Results  []SelectableElement `json:"results"`
+	Language string              `json:"language"`
+}
+
+// TopicOption represents a topic which the variables can be filtered by
+type TopicOption struct {
+	Value      string `json:"value"`
+	Label      string `json:"label"`
+	Count      int    `json:"count"`
+	IsSelected bool   `json:"is_selected"`
 }
@@ -1,7 +1,7 @@
 package model
 
 import (
-	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
 )
 
 /*
@@ -11,15 +11,33 @@ HasNoResults is a bool which displays messaging if there are no search results
 Results is an array of results
 Selections is an array of previously added selections
 Language is the user set language
+PageSizes is an array of the page sizes the results can be displayed with
+TotalCount is the number of results matching the search
+CanSelectAllMatching is a bool which displays the action to add every matching result
+SelectAllMatchingLabel is the human readable label of the action to add every matching result
+SelectAllLimitHint explains why there are too many matching results to add at once
+Pagination is the pagination of the results
 */
 type SearchOutput struct {
-	HasNoResults       bool                `json:"has_no_results"`
-	HasValidationError bool                `json:"has_validation_error"`
-	Results            []SelectableElement `json:"search_results"`
-	Selections         []SelectableElement `json:"selections"`
-	SelectionsTitle    string              `json:"selections_title"`
-	Language           string              `json:"language"`
-	coreModel.Pagination
+	HasNoResults           bool                `json:"has_no_results"`
+	HasValidationError     bool                `json:"has_validation_error"`
+	Results                []SelectableElement `json:"search_results"`
+	Selections             []SelectableElement `json:"selections"`
+	SelectionsTitle        string              `json:"selections_title"`
+	Language               string              `json:"language"`
+	PageSizes              []PageSize          `json:"page_sizes"`
+	TotalCount             int                 `json:"total_count"`
+	CanSelectAllMatching   bool                `json:"can_select_all_matching"`
+	SelectAllMatchingLabel string              `json:"select_all_matching_label"`
+	SelectAllLimitHint     string              `json:"select_all_limit_hint"`
+	Pagination             Pagination          `json:"pagination"`
+}
+
+// PageSize represents a page size which paginated results can be displayed with
+type PageSize struct {
+	Size      int    `json:"size"`
+	URL       string `json:"url"`
+	IsCurrent bool   `json:"is_current"`
 }
 
 /*
@@ -31,15 +49,20 @@ Value is the value sent to the server.
 Name is the name attribute.
 IsSelected is a boolean representing whether the element is selected.
 IsDisabled is a boolean representing whether the element is disabled
+Highlight is the text split around the part which matched a search query
+MatchedCategory is the label of the category which matched a search query
 */
 type SelectableElement struct {
-	Text             string `json:"text"`
-	InnerText        string `json:"inner_text"`
-	Value            string `json:"value"`
-	Name             string `json:"name"`
-	QualityStatement Panel  `json:"quality_statement"`
-	IsSelected       bool   `json:"is_selected"`
-	IsDisabled       bool   `json:"is_disabled"`
+	Text             string           `json:"text"`
+	InnerText        string           `json:"inner_text"`
+	Value            string           `json:"value"`
+	Name             string           `json:"name"`
+	QualityStatement Panel            `json:"quality_statement"`
+	IsSelected       bool             `json:"is_selected"`
+	IsDisabled       bool             `json:"is_disabled"`
+	Highlight        search.Highlight `json:"highlight"`
+	MatchedCategory  string           `json:"matched_category"`
+	URI              string           `json:"uri"`
 }
 
 // SearchField represents the data required to populate the search input partial
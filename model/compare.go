@@ -0,0 +1,45 @@
+package model
+
+import (
+	"github.com/ONSdigital/dp-api-clients-go/v2/cantabular"
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// Comparison represents the data to display the filter comparison page
+type Comparison struct {
+	coreModel.Page
+	FilterA        ComparedFilter  `json:"filter_a"`
+	FilterB        ComparedFilter  `json:"filter_b"`
+	Rows           []ComparisonRow `json:"rows"`
+	HasDifferences bool            `json:"has_differences"`
+	FeedbackAPIURL string          `json:"feedback_api_url"`
+}
+
+// ComparedFilter represents the summary of one of the filters being compared
+type ComparedFilter struct {
+	FilterID     string `json:"filter_id"`
+	DatasetTitle string `json:"dataset_title"`
+	URI          string `json:"uri"`
+	SDC          string `json:"sdc"`
+}
+
+// ComparisonRow represents a single difference between two filters
+type ComparisonRow struct {
+	Name        string `json:"name"`
+	ValueA      string `json:"value_a"`
+	ValueB      string `json:"value_b"`
+	Status      string `json:"status"`
+	IsDifferent bool   `json:"is_different"`
+}
+
+// FilterComparisonItem represents a DTO for a filter with the data required to compare it
+type FilterComparisonItem struct {
+	Filter         filter.GetFilterResponse
+	Dimensions     []filter.Dimension
+	BaseVariables  map[string]population.GetBaseVariableResponse
+	DatasetTitle   string
+	IsMultivariate bool
+	SDC            cantabular.GetBlockedAreaCountResult
+}
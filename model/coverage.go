@@ -7,17 +7,23 @@ import (
 // Coverage represents the data to display the coverage page
 type Coverage struct {
 	coreModel.Page
-	Geography          string              `json:"geography"`
-	Dimension          string              `json:"dimension"`
-	GeographyID        string              `json:"geography_id"`
-	ParentSelect       []SelectableElement `json:"parent_select"`
-	NameSearch         SearchField         `json:"name_search"`
-	ParentSearch       SearchField         `json:"parent_search"`
-	CoverageType       string              `json:"coverage_type"`
-	NameSearchOutput   SearchOutput        `json:"name_search_output"`
-	ParentSearchOutput SearchOutput        `json:"parent_search_output"`
-	IsSelectParents    bool                `json:"is_select_parents"`
-	OptionType         string              `json:"option_type"`
-	SetParent          string              `json:"set_parent"`
-	FeedbackAPIURL     string              `json:"feedback_api_url"`
+	Geography          string                   `json:"geography"`
+	Dimension          string                   `json:"dimension"`
+	GeographyID        string                   `json:"geography_id"`
+	ParentSelect       []SelectableElement      `json:"parent_select"`
+	NameSearch         SearchField              `json:"name_search"`
+	ParentSearch       SearchField              `json:"parent_search"`
+	CoverageType       string                   `json:"coverage_type"`
+	NameSearchOutput   SearchOutput             `json:"name_search_output"`
+	ParentSearchOutput SearchOutput             `json:"parent_search_output"`
+	BrowseLevels       []SelectableElement      `json:"browse_levels"`
+	BrowseLevel        string                   `json:"browse_level"`
+	BrowseOutput       SearchOutput             `json:"browse_output"`
+	BrowseParent       SelectableElement        `json:"browse_parent"`
+	BrowseBreadcrumbs  []coreModel.TaxonomyNode `json:"browse_breadcrumbs"`
+	IsSelectParents    bool                     `json:"is_select_parents"`
+	OptionType         string                   `json:"option_type"`
+	SetParent          string                   `json:"set_parent"`
+	FeedbackAPIURL     string                   `json:"feedback_api_url"`
+	DatasetContext     DatasetContext           `json:"dataset_context"`
 }
@@ -0,0 +1,11 @@
+package model
+
+// DatasetContext represents the dataset and release which a filter customises, shown on the pages of the filter
+type DatasetContext struct {
+	Title       string `json:"title"`
+	Edition     string `json:"edition"`
+	Version     string `json:"version"`
+	ReleaseDate string `json:"release_date"`
+	URI         string `json:"uri"`
+	Language    string `json:"language"`
+}
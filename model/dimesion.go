@@ -15,6 +15,12 @@ type Dimension struct {
 	HasCategories  bool     `json:"has_categories"`
 	HasChange      bool     `json:"has_change"`
 	FeedbackAPIURL string   `json:"feedback_api_url"`
+	CanMoveUp      bool     `json:"can_move_up"`
+	CanMoveDown    bool     `json:"can_move_down"`
+	ReorderURI     string   `json:"reorder_uri"`
+	CategoriesURI  string   `json:"categories_uri"`
+	Quality        Panel    `json:"quality"`
+	QualityURI     string   `json:"quality_uri"`
 }
 
 // FilterDimension represents a DTO for filter.Dimension with the additional OptionsCount field
@@ -23,3 +29,16 @@ type FilterDimension struct {
 	OptionsCount        int
 	CategorisationCount int
 }
+
+// SDCSuggestion represents an alternative categorisation or area type which blocks fewer areas than the current selection
+type SDCSuggestion struct {
+	Dimension       string
+	Value           string
+	Label           string
+	CurrentLabel    string
+	CategoriesCount int
+	IsAreaType      bool
+	Passed          int
+	Blocked         int
+	Total           int
+}
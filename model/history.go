@@ -0,0 +1,36 @@
+package model
+
+import (
+	"time"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/filter"
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// RecentFilters represents the data to display the recent filters page
+type RecentFilters struct {
+	coreModel.Page
+	Filters        []RecentFilter `json:"filters"`
+	FeedbackAPIURL string         `json:"feedback_api_url"`
+}
+
+// RecentFilter represents a summary of a filter in the user's history
+type RecentFilter struct {
+	FilterID     string   `json:"filter_id"`
+	DatasetTitle string   `json:"dataset_title"`
+	Dimensions   []string `json:"dimensions"`
+	LastChanged  string   `json:"last_changed"`
+	URI          string   `json:"uri"`
+	IsSubmitted  bool     `json:"is_submitted"`
+	IsExpired    bool     `json:"is_expired"`
+}
+
+// FilterHistoryItem represents a DTO for a filter in the user's history with the data required to summarise it
+type FilterHistoryItem struct {
+	FilterID     string
+	LastChanged  time.Time
+	Filter       filter.GetFilterResponse
+	Dimensions   []filter.Dimension
+	DatasetTitle string
+	IsExpired    bool
+}
@@ -15,7 +15,38 @@ type Overview struct {
 	DisableGetDataButton  bool        `json:"disable_get_data_button"`
 	HasSDC                bool        `json:"has_sdc"`
 	MaxVariableError      bool        `json:"max_variable_error"`
+	BlockedAreasURI       string      `json:"blocked_areas_uri"`
 	FeedbackAPIURL        string      `json:"feedback_api_url"`
 	ImproveResults        coreModel.Collapsible
+	Suggestions           []Suggestion `json:"suggestions"`
 	DimensionDescriptions coreModel.Collapsible
+	DatasetContext        DatasetContext `json:"dataset_context"`
+	NewerVersion          NewerVersion   `json:"newer_version"`
+	QualityNotes          []QualityNote  `json:"quality_notes"`
+}
+
+// QualityNote represents the quality statement and summary of a variable of the table
+type QualityNote struct {
+	Dimension string `json:"dimension"`
+	Text      string `json:"text"`
+	URI       string `json:"uri"`
+}
+
+// NewerVersion represents a later version of the dataset which the filter can be rebuilt against
+type NewerVersion struct {
+	HasNewerVersion bool     `json:"has_newer_version"`
+	Edition         string   `json:"edition"`
+	Version         string   `json:"version"`
+	ReleaseDate     string   `json:"release_date"`
+	URI             string   `json:"uri"`
+	RebuildURI      string   `json:"rebuild_uri"`
+	Missing         []string `json:"missing"`
+}
+
+// Suggestion represents a change to the filter which would reduce the number of blocked areas
+type Suggestion struct {
+	Text       string `json:"text"`
+	URI        string `json:"uri"`
+	Dimension  string `json:"dimension"`
+	IsAreaType bool   `json:"is_area_type"`
 }
@@ -0,0 +1,36 @@
+package model
+
+// Pagination represents the data required to display accessible pagination of results
+type Pagination struct {
+	CurrentPage int              `json:"current_page"`
+	TotalPages  int              `json:"total_pages"`
+	Limit       int              `json:"limit"`
+	Summary     string           `json:"summary"`
+	Label       string           `json:"label"`
+	Previous    PaginationLink   `json:"previous"`
+	Next        PaginationLink   `json:"next"`
+	Items       []PaginationItem `json:"items"`
+	RelLinks    []RelLink        `json:"rel_links"`
+}
+
+// PaginationLink represents a link to the previous or next page, the URL is empty when there is no such page
+type PaginationLink struct {
+	Text  string `json:"text"`
+	Label string `json:"label"`
+	URL   string `json:"url"`
+}
+
+// PaginationItem represents a numbered page or an ellipsis marking pages which are not displayed
+type PaginationItem struct {
+	PageNumber int    `json:"page_number"`
+	Label      string `json:"label"`
+	URL        string `json:"url"`
+	IsCurrent  bool   `json:"is_current"`
+	IsEllipsis bool   `json:"is_ellipsis"`
+}
+
+// RelLink represents a link relation to an adjacent page for the head of the document
+type RelLink struct {
+	Rel string `json:"rel"`
+	URL string `json:"url"`
+}
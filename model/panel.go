@@ -7,6 +7,7 @@ const (
 	Pending
 	Success
 	Error
+	Warn
 )
 
 // FuncGetPanelType returns the panel type as a string
@@ -20,6 +21,8 @@ func (p Panel) FuncGetPanelType() (panelType string) {
 		return "success"
 	case Error:
 		return "error"
+	case Warn:
+		return "warn"
 	}
 	return panelType
 }
@@ -0,0 +1,20 @@
+package model
+
+import coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+
+// PopulationTypeSelector represents page data for changing the population type of a filter
+type PopulationTypeSelector struct {
+	coreModel.Page
+	PopulationType    string         `json:"population_type"`
+	Selections        []Selection    `json:"selections"`
+	InitialSelection  string         `json:"initial_selection"`
+	HasReview         bool           `json:"has_review"`
+	Selected          string         `json:"selected"`
+	KeptVariables     []string       `json:"kept_variables"`
+	DroppedVariables  []string       `json:"dropped_variables"`
+	DroppedAreas      []string       `json:"dropped_areas"`
+	AreaType          string         `json:"area_type"`
+	IsAreaTypeChanged bool           `json:"is_area_type_changed"`
+	CancelURI         string         `json:"cancel_uri"`
+	DatasetContext    DatasetContext `json:"dataset_context"`
+}
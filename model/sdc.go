@@ -0,0 +1,31 @@
+package model
+
+import (
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// SDCAreas represents the data to display the blocked areas page
+type SDCAreas struct {
+	coreModel.Page
+	FilterID         string         `json:"filter_id"`
+	Panel            Panel          `json:"panel"`
+	Geography        string         `json:"geography"`
+	HasCoverage      bool           `json:"has_coverage"`
+	CoverageURI      string         `json:"coverage_uri"`
+	Areas            []SDCArea      `json:"areas"`
+	ShowRemoveButton bool           `json:"show_remove_button"`
+	FeedbackAPIURL   string         `json:"feedback_api_url"`
+	DatasetContext   DatasetContext `json:"dataset_context"`
+	Pagination       Pagination     `json:"pagination"`
+}
+
+// SDCArea represents the disclosure control result for a single coverage option
+type SDCArea struct {
+	ID        string `json:"id"`
+	Label     string `json:"label"`
+	IsBlocked bool   `json:"is_blocked"`
+	Passed    int    `json:"passed"`
+	Blocked   int    `json:"blocked"`
+	Total     int    `json:"total"`
+	Status    string `json:"status"`
+}
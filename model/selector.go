@@ -11,10 +11,11 @@ type Selector struct {
 	Selections       []Selection
 	InitialSelection string
 	IsAreaType       bool
-	LeadText         string `json:"lead_text"`
-	ErrorId          string `json:"error_id"`
-	Panel            Panel  `json:"panel"`
-	FeedbackAPIURL   string `json:"feedback_api_url"`
+	LeadText         string         `json:"lead_text"`
+	ErrorId          string         `json:"error_id"`
+	Panel            Panel          `json:"panel"`
+	FeedbackAPIURL   string         `json:"feedback_api_url"`
+	DatasetContext   DatasetContext `json:"dataset_context"`
 }
 
 // Selection represents a dimension selection (e.g. an Area-type of City)
@@ -28,4 +29,8 @@ type Selection struct {
 	IsTruncated     bool
 	TruncateLink    string
 	IsSuggested     bool
+	Examples        []string
+	Parents         []string
+	SDCText         string
+	IsRecommended   bool
 }
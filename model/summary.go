@@ -0,0 +1,41 @@
+package model
+
+import (
+	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
+)
+
+// FilterSummary represents the data to display a printable summary of everything requested by a filter
+type FilterSummary struct {
+	coreModel.Page
+	FilterID        string            `json:"filter_id"`
+	DatasetContext  DatasetContext    `json:"dataset_context"`
+	PopulationType  SummaryItem       `json:"population_type"`
+	AreaType        SummaryItem       `json:"area_type"`
+	Coverage        []SummaryItem     `json:"coverage"`
+	DefaultCoverage string            `json:"default_coverage"`
+	Variables       []SummaryVariable `json:"variables"`
+	HasSDC          bool              `json:"has_sdc"`
+	SDC             SummarySDC        `json:"sdc"`
+	OverviewURI     string            `json:"overview_uri"`
+	CSVURI          string            `json:"csv_uri"`
+}
+
+// SummaryItem represents a code and label of a filter summary, e.g. an area or a category
+type SummaryItem struct {
+	Code  string `json:"code"`
+	Label string `json:"label"`
+}
+
+// SummaryVariable represents a variable of a filter summary with its categorisation and every selected category
+type SummaryVariable struct {
+	Code       string        `json:"code"`
+	Label      string        `json:"label"`
+	Categories []SummaryItem `json:"categories"`
+}
+
+// SummarySDC represents the result of statistical disclosure control for the areas of a filter
+type SummarySDC struct {
+	Passed  int `json:"passed"`
+	Blocked int `json:"blocked"`
+	Total   int `json:"total"`
+}
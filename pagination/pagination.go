@@ -6,9 +6,14 @@ import (
 	"net/url"
 	"strconv"
 
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
 	coreModel "github.com/ONSdigital/dp-renderer/v2/model"
 )
 
+// PageSizes are the page sizes which users can choose between
+var PageSizes = []int{10, 20, 50, 100}
+
 // GetTotalPages returns the total pages from the total results count and pagesize parameters
 func GetTotalPages(totalCount, pageSize int) int {
 	if totalCount <= 0 || pageSize <= 0 {
@@ -36,8 +41,92 @@ func GetOffset(pageSize, pageNo int) int {
 	return (pageSize * pageNo) - pageSize
 }
 
+// GetPageSize returns the requested page size if it is one of the PageSizes, otherwise the default page size
+func GetPageSize(size string, defaultSize int) int {
+	requested, err := strconv.Atoi(size)
+	if err != nil {
+		return defaultSize
+	}
+	for _, ps := range PageSizes {
+		if ps == requested {
+			return requested
+		}
+	}
+	return defaultSize
+}
+
+// GetPageSizeUrl returns the url for the first page of results with the given page size that retains existing query string parameters
+func GetPageSizeUrl(req *http.Request, size int) string {
+	u, _ := url.Parse(fmt.Sprint(req.URL))
+
+	q := u.Query()
+	q.Set("size", strconv.Itoa(size))
+	q.Del("page")
+	u.RawQuery = q.Encode()
+
+	return fmt.Sprint(u)
+}
+
+// GetPagination returns the pagination of results with previous and next links, the first and last pages either side of
+// the pages around the current page separated by ellipsis markers, and a localised summary of the current page.
+// A current page outside of the range of pages is treated as the nearest page in the range.
+func GetPagination(req *http.Request, currentPage, totalPages, limit int, lang string) model.Pagination {
+	if totalPages < 1 {
+		return model.Pagination{}
+	}
+	currentPage = max(1, min(currentPage, totalPages))
+
+	summary := helper.Localise("PaginationSummary", lang, 1, strconv.Itoa(currentPage), strconv.Itoa(totalPages))
+	p := model.Pagination{
+		CurrentPage: currentPage,
+		TotalPages:  totalPages,
+		Limit:       limit,
+		Summary:     summary,
+		Label:       helper.Localise("PaginationLabel", lang, 1, summary),
+	}
+
+	if currentPage > 1 {
+		p.Previous = model.PaginationLink{
+			Text:  helper.Localise("PaginationPrevious", lang, 1),
+			Label: helper.Localise("PaginationPreviousLabel", lang, 1, strconv.Itoa(currentPage-1)),
+			URL:   getPageUrl(req, currentPage-1),
+		}
+		p.RelLinks = append(p.RelLinks, model.RelLink{Rel: "prev", URL: p.Previous.URL})
+	}
+	if currentPage < totalPages {
+		p.Next = model.PaginationLink{
+			Text:  helper.Localise("PaginationNext", lang, 1),
+			Label: helper.Localise("PaginationNextLabel", lang, 1, strconv.Itoa(currentPage+1)),
+			URL:   getPageUrl(req, currentPage+1),
+		}
+		p.RelLinks = append(p.RelLinks, model.RelLink{Rel: "next", URL: p.Next.URL})
+	}
+
+	start, end := getWindowStartEndPage(currentPage, totalPages, getPageRange(totalPages))
+	if start > 1 {
+		p.Items = append(p.Items, getPaginationItem(req, 1, currentPage, totalPages, lang))
+	}
+	if start > 2 {
+		p.Items = append(p.Items, model.PaginationItem{IsEllipsis: true})
+	}
+	for i := start; i <= end; i++ {
+		p.Items = append(p.Items, getPaginationItem(req, i, currentPage, totalPages, lang))
+	}
+	if end < totalPages-1 {
+		p.Items = append(p.Items, model.PaginationItem{IsEllipsis: true})
+	}
+	if end < totalPages {
+		p.Items = append(p.Items, getPaginationItem(req, totalPages, currentPage, totalPages, lang))
+	}
+
+	return p
+}
+
 // GetPagesToDisplay returns the pages to be displayed within the first and last pages
 func GetPagesToDisplay(currentPage, totalPages int, req *http.Request) []coreModel.PageToDisplay {
+	if totalPages < 1 {
+		return nil
+	}
 	pageRange := getPageRange(totalPages)
 	start, end := getWindowStartEndPage(currentPage, totalPages, pageRange)
 
@@ -69,11 +158,14 @@ func GetFirstAndLastPages(req *http.Request, totalPages int) []coreModel.PageToD
 
 // getWindowStartEndPage calculates the start and end page of the moving window of size windowSize, over the set of pages
 // whose current page is currentPage, and whose size is totalPages
-// It is an error to pass a parameter whose value is < 1, or a currentPage > totalPages, and the function will panic in this case
+// A currentPage outside of the set of pages is treated as the nearest page, a windowSize < 1 as a window of one page,
+// and a start and end page of 0 is returned when there are no pages
 func getWindowStartEndPage(currentPage, totalPages, windowSize int) (int, int) {
-	if currentPage < 1 || totalPages < 1 || windowSize < 1 || currentPage > totalPages {
-		panic("invalid parameters for getWindowStartEndPage - see documentation")
+	if totalPages < 1 {
+		return 0, 0
 	}
+	currentPage = max(1, min(currentPage, totalPages))
+	windowSize = max(1, windowSize)
 	switch {
 	case windowSize == 1:
 		se := (currentPage % totalPages) + 1
@@ -99,6 +191,20 @@ func getWindowStartEndPage(currentPage, totalPages, windowSize int) (int, int) {
 	return start, end
 }
 
+// getPaginationItem returns a numbered page of the pagination
+func getPaginationItem(req *http.Request, pg, currentPage, totalPages int, lang string) model.PaginationItem {
+	label := helper.Localise("PaginationSummary", lang, 1, strconv.Itoa(pg), strconv.Itoa(totalPages))
+	if pg == currentPage {
+		label = helper.Localise("PaginationCurrentLabel", lang, 1, label)
+	}
+	return model.PaginationItem{
+		PageNumber: pg,
+		Label:      label,
+		URL:        getPageUrl(req, pg),
+		IsCurrent:  pg == currentPage,
+	}
+}
+
 func getWindowOffset(windowSize int) int {
 	if windowSize%2 == 0 {
 		return (windowSize / 2) - 1
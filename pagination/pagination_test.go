@@ -6,6 +6,9 @@ import (
 	"strconv"
 	"testing"
 
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/mocks"
+	fModel "github.com/ONSdigital/dp-frontend-filter-flex-dataset/model"
+	"github.com/ONSdigital/dp-renderer/v2/helper"
 	"github.com/ONSdigital/dp-renderer/v2/model"
 	. "github.com/smartystreets/goconvey/convey"
 )
@@ -209,6 +212,132 @@ func TestGetFirstAndLastPages(t *testing.T) {
 	})
 }
 
+func TestGetPageSize(t *testing.T) {
+	t.Parallel()
+	Convey("Given a requested page size and a default page size of 50", t, func() {
+		testcases := []struct {
+			size         string
+			expectedSize int
+		}{
+			{size: "10", expectedSize: 10},
+			{size: "100", expectedSize: 100},
+			{size: "", expectedSize: 50},
+			{size: "15", expectedSize: 50},
+			{size: "1000", expectedSize: 50},
+			{size: "all", expectedSize: 50},
+		}
+		Convey("When the 'GetPageSize' function is called", func() {
+			for _, tc := range testcases {
+				sut := GetPageSize(tc.size, 50)
+				Convey(fmt.Sprintf("Then a requested size of '%s' gives a page size of %s", tc.size, strconv.Itoa(tc.expectedSize)), func() {
+					So(sut, ShouldEqual, tc.expectedSize)
+				})
+			}
+		})
+	})
+}
+
+func TestGetPageSizeUrl(t *testing.T) {
+	t.Parallel()
+	Convey("Given an http request for a paginated page", t, func() {
+		req := httptest.NewRequest("GET", "/a/page?q=hart&page=3&size=10", nil)
+
+		Convey("When the 'GetPageSizeUrl' function is called", func() {
+			sut := GetPageSizeUrl(req, 20)
+
+			Convey("Then the url retains the query, resets the page and sets the page size", func() {
+				So(sut, ShouldEqual, "/a/page?q=hart&size=20")
+			})
+		})
+	})
+
+	Convey("Given a page size is in the query string", t, func() {
+		req := httptest.NewRequest("GET", "/a/page?q=hart&size=10", nil)
+
+		Convey("When the 'GetFirstAndLastPages' function is called", func() {
+			sut := GetFirstAndLastPages(req, 2)
+
+			Convey("Then the page size is kept in the page urls", func() {
+				So(sut[1].URL, ShouldEqual, "/a/page?page=2&q=hart&size=10")
+			})
+		})
+	})
+}
+
+func TestGetPagination(t *testing.T) {
+	helper.InitialiseLocalisationsHelper(mocks.MockAssetFunction)
+	req := httptest.NewRequest("GET", "/a/page?q=hart", nil)
+
+	Convey("Given the current page is in the middle of many pages", t, func() {
+		sut := GetPagination(req, 5, 10, 50, "en")
+
+		Convey("Then the pages around the current page are displayed between the first and last pages", func() {
+			pages := []int{}
+			for _, item := range sut.Items {
+				pages = append(pages, item.PageNumber)
+			}
+			So(pages, ShouldResemble, []int{1, 0, 4, 5, 6, 0, 10})
+			So(sut.Items[1].IsEllipsis, ShouldBeTrue)
+			So(sut.Items[5].IsEllipsis, ShouldBeTrue)
+			So(sut.Items[3].IsCurrent, ShouldBeTrue)
+			So(sut.Items[3].Label, ShouldEqual, "Current page (Page 5 of 10)")
+			So(sut.Items[6].URL, ShouldEqual, "/a/page?page=10&q=hart")
+		})
+		Convey("Then previous and next links and rel links are returned", func() {
+			So(sut.Previous, ShouldResemble, fModel.PaginationLink{
+				Text:  "Previous",
+				Label: "Go to the previous page (Page 4)",
+				URL:   "/a/page?page=4&q=hart",
+			})
+			So(sut.Next.URL, ShouldEqual, "/a/page?page=6&q=hart")
+			So(sut.RelLinks, ShouldResemble, []fModel.RelLink{
+				{Rel: "prev", URL: "/a/page?page=4&q=hart"},
+				{Rel: "next", URL: "/a/page?page=6&q=hart"},
+			})
+		})
+		Convey("Then the summary is localised", func() {
+			So(sut.Summary, ShouldEqual, "Page 5 of 10")
+			So(sut.Label, ShouldEqual, "Pagination (Page 5 of 10)")
+			So(GetPagination(req, 5, 10, 50, "cy").Summary, ShouldEqual, "Page 5 of 10 (cy)")
+		})
+	})
+
+	Convey("Given the current page is next to the first page", t, func() {
+		sut := GetPagination(req, 2, 10, 50, "en")
+
+		Convey("Then there is no ellipsis between the first page and the current pages", func() {
+			So(sut.Items[0].PageNumber, ShouldEqual, 1)
+			So(sut.Items[1].PageNumber, ShouldEqual, 2)
+			So(sut.Items[1].IsEllipsis, ShouldBeFalse)
+		})
+	})
+
+	Convey("Given the current page is the first page", t, func() {
+		sut := GetPagination(req, 1, 3, 50, "en")
+
+		Convey("Then there is no previous link", func() {
+			So(sut.Previous, ShouldResemble, fModel.PaginationLink{})
+			So(sut.RelLinks, ShouldHaveLength, 1)
+			So(sut.Items, ShouldHaveLength, 3)
+		})
+	})
+
+	Convey("Given the current page is out of range", t, func() {
+		Convey("Then the nearest page is used without panicking", func() {
+			So(func() { GetPagination(req, 12, 10, 50, "en") }, ShouldNotPanic)
+			So(GetPagination(req, 12, 10, 50, "en").CurrentPage, ShouldEqual, 10)
+			So(GetPagination(req, -1, 10, 50, "en").CurrentPage, ShouldEqual, 1)
+		})
+	})
+
+	Convey("Given there are no pages", t, func() {
+		Convey("Then an empty pagination is returned", func() {
+			So(GetPagination(req, 1, 0, 50, "en"), ShouldResemble, fModel.Pagination{})
+			So(GetPagesToDisplay(1, 0, req), ShouldBeEmpty)
+		})
+	})
+}
+
 func TestGetStartEndPage(t *testing.T) {
 	t.Parallel()
 	Convey("Given a set of parameters expressing: the 'current page number', out of a 'total number of pages', and the 'window size'", t, func() {
@@ -231,6 +360,11 @@ func TestGetStartEndPage(t *testing.T) {
 
 			{current: 28, total: 32, window: 5, exStart: 26, exEnd: 30},
 			{current: 31, total: 32, window: 5, exStart: 28, exEnd: 32},
+
+			{current: 0, total: 10, window: 3, exStart: 1, exEnd: 3},
+			{current: 12, total: 10, window: 3, exStart: 8, exEnd: 10},
+			{current: 1, total: 0, window: 3, exStart: 0, exEnd: 0},
+			{current: 2, total: 3, window: 0, exStart: 3, exEnd: 3},
 		}
 		Convey("check the generated start and end page numbers are correct", func() {
 			for _, tc := range testcases {
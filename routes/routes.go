@@ -10,6 +10,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/population"
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/handlers"
 	render "github.com/ONSdigital/dp-renderer/v2"
 
@@ -26,23 +27,42 @@ type Clients struct {
 	Dimension          *dimension.Client
 	Population         *population.Client
 	Zebedee            *zebedee.Client
+	Geography          *geography.Rules
 }
 
 // Setup registers routes for the service
 func Setup(ctx context.Context, r *mux.Router, cfg *config.Config, c Clients) {
 	log.Info(ctx, "adding routes")
 
-	ff := handlers.NewFilterFlex(c.Render, c.Filter, c.Dataset, c.Population, c.Zebedee, cfg)
+	ff := handlers.NewFilterFlex(c.Render, c.Filter, c.Dataset, c.Population, c.Zebedee, c.Geography, cfg)
 
 	r.StrictSlash(true).Path("/health").HandlerFunc(c.HealthCheckHandler)
 
+	if cfg.EnableFilterHistory {
+		r.StrictSlash(true).Path("/filters/recent").Methods("GET").HandlerFunc(ff.RecentFilters())
+	}
+
 	r.StrictSlash(true).Path("/filters/{filterID}/submit").Methods("POST").HandlerFunc(ff.Submit())
+	r.StrictSlash(true).Path("/filters/{filterID}/duplicate").Methods("POST").HandlerFunc(ff.Duplicate())
+	r.StrictSlash(true).Path("/filters/{filterID}/rebuild").Methods("POST").HandlerFunc(ff.Rebuild())
+	r.StrictSlash(true).Path("/filters/{filterID}/population-type").Methods("GET").HandlerFunc(ff.PopulationTypeSelector())
+	r.StrictSlash(true).Path("/filters/{filterID}/population-type").Methods("POST").HandlerFunc(ff.ChangePopulationType())
+	r.StrictSlash(true).Path("/filters/{filterID}/summary").Methods("GET").HandlerFunc(ff.FilterSummary())
+	r.StrictSlash(true).Path("/filters/{filterID}/summary.csv").Methods("GET").HandlerFunc(ff.FilterSummaryCSV())
 
 	r.StrictSlash(true).Path("/filters/{filterID}/dimensions").Methods("GET").HandlerFunc(ff.FilterFlexOverview())
 	if cfg.EnableMultivariate {
+		r.StrictSlash(true).Path("/filters/compare").Methods("GET").HandlerFunc(ff.Compare())
 		r.StrictSlash(true).Path("/filters/{filterID}/dimensions/change").Methods("GET").HandlerFunc(ff.GetChangeDimensions())
 		r.StrictSlash(true).Path("/filters/{filterID}/dimensions/change").Methods("POST").HandlerFunc(ff.PostChangeDimensions())
+		r.StrictSlash(true).Path("/filters/{filterID}/sdc/areas").Methods("GET").HandlerFunc(ff.GetSDCAreas())
+		r.StrictSlash(true).Path("/filters/{filterID}/sdc/areas").Methods("POST").HandlerFunc(ff.RemoveBlockedAreas())
 	}
+	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/reorder").Methods("POST").HandlerFunc(ff.ReorderDimensions())
+	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}/categories").Methods("GET").HandlerFunc(ff.CategorySelector())
+	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}/categories").Methods("POST").HandlerFunc(ff.UpdateCategories())
+	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}/review").Methods("GET").HandlerFunc(ff.AreaTypeReview())
+	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}/review").Methods("POST").HandlerFunc(ff.ChangeAreaType())
 	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}").Methods("GET").HandlerFunc(ff.DimensionSelector())
 	r.StrictSlash(true).Path("/filters/{filterID}/dimensions/{name}").Methods("POST").HandlerFunc(ff.ChangeDimension())
 
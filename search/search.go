@@ -0,0 +1,182 @@
+package search
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	minPrefixLength = 4
+	maxPrefixTrim   = 3
+)
+
+var areaCodeRegex = regexp.MustCompile(`^[A-Za-z]\d{8}$`)
+
+// Highlight represents text split around the part which matched a search query
+type Highlight struct {
+	Before string `json:"before"`
+	Match  string `json:"match"`
+	After  string `json:"after"`
+}
+
+// IsAreaCode determines whether the query is a GSS area code, for example E92000001
+func IsAreaCode(query string) bool {
+	return areaCodeRegex.MatchString(strings.TrimSpace(query))
+}
+
+// Normalise returns a lower case form of the text without diacritics, treating hyphens as spaces.
+// Each rune of the text maps to a single rune so positions in the normalised text match the original.
+func Normalise(text string) string {
+	var b strings.Builder
+	for _, r := range text {
+		b.WriteRune(normaliseRune(r))
+	}
+	return b.String()
+}
+
+// Variants returns alternative forms of the query to retry a search with when it has no results.
+// The variants are ordered from the closest to the original query and do not include the query itself.
+func Variants(query string) []string {
+	query = strings.TrimSpace(query)
+	normalised := strings.Join(strings.Fields(Normalise(query)), " ")
+
+	candidates := []string{normalised}
+	candidates = append(candidates, saintVariants(normalised)...)
+	if strings.Contains(normalised, " ") {
+		candidates = append(candidates, strings.ReplaceAll(normalised, " ", "-"))
+	}
+
+	// shorter prefixes of the query allow for typos at the end of a word, for example "Manchestr"
+	runes := []rune(normalised)
+	for i := 1; i <= maxPrefixTrim && len(runes)-i >= minPrefixLength; i++ {
+		candidates = append(candidates, string(runes[:len(runes)-i]))
+	}
+
+	seen := map[string]bool{query: true}
+	var variants []string
+	for _, c := range candidates {
+		if c == "" || seen[c] {
+			continue
+		}
+		seen[c] = true
+		variants = append(variants, c)
+	}
+	return variants
+}
+
+// Rank orders the areas by how closely they match the query.
+// Exact matches on the label or area code come first, followed by prefix, word prefix and substring matches, then the closest spellings.
+func Rank(query string, areas []population.Area) []population.Area {
+	q := strings.Join(strings.Fields(Normalise(query)), " ")
+	ranked := append([]population.Area{}, areas...)
+	scores := make(map[string]int, len(ranked))
+	for _, area := range ranked {
+		scores[area.ID] = score(q, area)
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return scores[ranked[i].ID] < scores[ranked[j].ID]
+	})
+	return ranked
+}
+
+// HighlightMatch splits the text around the first part which matches the query or one of its variants.
+// An empty Match is returned when nothing matches.
+func HighlightMatch(text, query string) Highlight {
+	if strings.TrimSpace(query) == "" {
+		return Highlight{}
+	}
+	runes := []rune(text)
+	normalised := Normalise(text)
+	for _, candidate := range append([]string{Normalise(strings.TrimSpace(query))}, Variants(query)...) {
+		i := strings.Index(normalised, candidate)
+		if i < 0 {
+			continue
+		}
+		// convert byte offsets in the normalised text to rune offsets in the original text
+		start := len([]rune(normalised[:i]))
+		end := start + len([]rune(candidate))
+		return Highlight{
+			Before: string(runes[:start]),
+			Match:  string(runes[start:end]),
+			After:  string(runes[end:]),
+		}
+	}
+	return Highlight{}
+}
+
+// score returns how closely the area matches the normalised query, where lower scores are closer matches
+func score(q string, area population.Area) int {
+	label := Normalise(area.Label)
+	switch {
+	case label == q || strings.EqualFold(area.ID, q):
+		return 0
+	case strings.HasPrefix(label, q):
+		return 1
+	case strings.Contains(label, " "+q):
+		return 2
+	case strings.Contains(label, q):
+		return 3
+	}
+	return 4 + distance(q, label)
+}
+
+// saintVariants returns the query with "st" and "saint" swapped
+func saintVariants(q string) []string {
+	var variants []string
+	words := strings.Fields(q)
+	for i, word := range words {
+		var swap string
+		switch word {
+		case "st", "st.":
+			swap = "saint"
+		case "saint":
+			swap = "st"
+		default:
+			continue
+		}
+		swapped := append([]string{}, words...)
+		swapped[i] = swap
+		variants = append(variants, strings.Join(swapped, " "))
+	}
+	return variants
+}
+
+// normaliseRune lower cases the rune, removes any diacritic and replaces hyphens with spaces
+func normaliseRune(r rune) rune {
+	if r == '-' {
+		return ' '
+	}
+	decomposed := []rune(norm.NFD.String(string(r)))
+	base := decomposed[0]
+	if unicode.Is(unicode.Mn, base) {
+		base = r
+	}
+	return unicode.ToLower(base)
+}
+
+// distance returns the Levenshtein distance between two strings
+func distance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr := make([]int, len(rb)+1)
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-api-clients-go/v2/population"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestIsAreaCode(t *testing.T) {
+	Convey("Area codes are recognised", t, func() {
+		So(IsAreaCode("E92000001"), ShouldBeTrue)
+		So(IsAreaCode(" w06000015 "), ShouldBeTrue)
+	})
+	Convey("Area names are not area codes", t, func() {
+		So(IsAreaCode("Cardiff"), ShouldBeFalse)
+		So(IsAreaCode("E9200001"), ShouldBeFalse)
+	})
+}
+
+func TestNormalise(t *testing.T) {
+	Convey("Text is lower cased without diacritics and hyphens", t, func() {
+		So(Normalise("Ynys Môn"), ShouldEqual, "ynys mon")
+		So(Normalise("Stockton-on-Tees"), ShouldEqual, "stockton on tees")
+	})
+	Convey("The normalised text has the same number of runes", t, func() {
+		So([]rune(Normalise("Bro Morgannwg - Pen-y-bont ar Ogwr")), ShouldHaveLength, len([]rune("Bro Morgannwg - Pen-y-bont ar Ogwr")))
+	})
+}
+
+func TestVariants(t *testing.T) {
+	Convey("Given a query with diacritics and capitals", t, func() {
+		So(Variants("Môn")[0], ShouldEqual, "mon")
+	})
+	Convey("Given a query with saint", t, func() {
+		variants := Variants("St Albans")
+		So(variants, ShouldContain, "saint albans")
+		So(variants, ShouldContain, "st-albans")
+	})
+	Convey("Given a query with a typo", t, func() {
+		variants := Variants("Manchestr")
+		So(variants, ShouldContain, "manchest")
+		So(variants, ShouldContain, "manches")
+		So(variants, ShouldContain, "manche")
+		So(variants, ShouldNotContain, "Manchestr")
+	})
+	Convey("Given a short query", t, func() {
+		So(Variants("bath"), ShouldBeEmpty)
+	})
+}
+
+func TestRank(t *testing.T) {
+	areas := []population.Area{
+		{ID: "E08000009", Label: "Trafford"},
+		{ID: "E08000003", Label: "Greater Manchester"},
+		{ID: "E06000001", Label: "Manchester Airport"},
+		{ID: "E08000001", Label: "Manchester"},
+	}
+
+	Convey("Exact and prefix matches are ranked first", t, func() {
+		ranked := Rank("manchester", areas)
+		So(ranked[0].Label, ShouldEqual, "Manchester")
+		So(ranked[1].Label, ShouldEqual, "Manchester Airport")
+		So(ranked[2].Label, ShouldEqual, "Greater Manchester")
+		So(ranked[3].Label, ShouldEqual, "Trafford")
+	})
+	Convey("Area codes are ranked first", t, func() {
+		So(Rank("E08000009", areas)[0].Label, ShouldEqual, "Trafford")
+	})
+	Convey("The given areas are not modified", t, func() {
+		Rank("manchester", areas)
+		So(areas[0].Label, ShouldEqual, "Trafford")
+	})
+}
+
+func TestHighlightMatch(t *testing.T) {
+	Convey("The matched text is highlighted", t, func() {
+		So(HighlightMatch("Greater Manchester", "manchester"), ShouldResemble, Highlight{
+			Before: "Greater ",
+			Match:  "Manchester",
+		})
+	})
+	Convey("Matches without diacritics highlight the original text", t, func() {
+		So(HighlightMatch("Ynys Môn", "mon"), ShouldResemble, Highlight{
+			Before: "Ynys ",
+			Match:  "Môn",
+		})
+	})
+	Convey("Typos highlight the matching prefix", t, func() {
+		So(HighlightMatch("Manchester", "Manchestr"), ShouldResemble, Highlight{
+			Match: "Manchest",
+			After: "er",
+		})
+	})
+	Convey("Text that does not match is not highlighted", t, func() {
+		So(HighlightMatch("Trafford", "manchester"), ShouldResemble, Highlight{})
+		So(HighlightMatch("Trafford", ""), ShouldResemble, Highlight{})
+	})
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/ONSdigital/dp-api-clients-go/v2/zebedee"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/config"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/geography"
 	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/routes"
 	render "github.com/ONSdigital/dp-renderer/v2"
 	"github.com/ONSdigital/dp-renderer/v2/middleware/renderror"
@@ -53,6 +54,11 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 	svc.Config = cfg
 	svc.ServiceList = serviceList
 
+	geographyRules, err := geography.Load(cfg.GeographyRulesPath)
+	if err != nil {
+		return fmt.Errorf("failed to load geography rules: %w", err)
+	}
+
 	// Get health client for api router
 	svc.routerHealthClient = serviceList.GetHealthClient("api-router", cfg.APIRouterURL)
 
@@ -74,6 +80,7 @@ func (svc *Service) Init(ctx context.Context, cfg *config.Config, serviceList *E
 		Dimension:  dimensionClient,
 		Population: populationClient,
 		Zebedee:    zebedee.NewWithHealthClient(svc.routerHealthClient),
+		Geography:  geographyRules,
 	}
 
 	// Get healthcheck with checkers
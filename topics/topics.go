@@ -0,0 +1,100 @@
+package topics
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/helpers"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+// Other is the id of the topic of variables which are not in the topic mapping
+const Other = "other"
+
+const lang = "cy"
+
+// Topic represents a group of related variables
+type Topic struct {
+	ID      string
+	English string
+	Welsh   string
+}
+
+var (
+	once      sync.Once
+	variables map[string]string
+	topics    map[string]Topic
+	order     []string
+)
+
+// Label returns the name of the topic in the given language
+func (t Topic) Label(language string) string {
+	if language == lang && t.Welsh != "" {
+		return t.Welsh
+	}
+	return t.English
+}
+
+// ForVariable returns the topic of the variable, ignoring any categorisation suffix of the variable id
+func ForVariable(id string) (Topic, bool) {
+	load()
+	topic, ok := variables[strings.ToLower(helpers.TrimCategoryValue(id))]
+	if !ok {
+		return Topic{}, false
+	}
+	return topics[topic], true
+}
+
+// Index returns the position of the topic in the topic mapping, topics which are not in the mapping come last
+func Index(id string) int {
+	load()
+	for i, topic := range order {
+		if topic == id {
+			return i
+		}
+	}
+	return len(order)
+}
+
+// load parses the embedded variable topics once
+func load() {
+	once.Do(func() {
+		var err error
+		variables, topics, order, err = parse(assets.VariableTopics)
+		if err != nil {
+			log.Error(context.Background(), "failed to parse variable topics", err)
+		}
+	})
+}
+
+// parse reads a csv of variable ids with their topic, preserving the order in which topics first appear
+func parse(data []byte) (map[string]string, map[string]Topic, []string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, nil, errors.New("missing header")
+	}
+
+	parsedVariables := make(map[string]string, len(records)-1)
+	parsedTopics := map[string]Topic{}
+	var topicOrder []string
+	for _, record := range records[1:] {
+		if len(record) != 4 || record[0] == "" || record[1] == "" || record[2] == "" {
+			return nil, nil, nil, errors.New("invalid record: " + strings.Join(record, ","))
+		}
+		id := record[1]
+		if _, ok := parsedTopics[id]; !ok {
+			parsedTopics[id] = Topic{ID: id, English: record[2], Welsh: record[3]}
+			topicOrder = append(topicOrder, id)
+		}
+		parsedVariables[strings.ToLower(record[0])] = id
+	}
+	return parsedVariables, parsedTopics, topicOrder, nil
+}
@@ -0,0 +1,50 @@
+package topics
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse(t *testing.T) {
+	Convey("The embedded variable topics are valid", t, func() {
+		variables, topics, order, err := parse(assets.VariableTopics)
+		So(err, ShouldBeNil)
+		So(variables, ShouldNotBeEmpty)
+		So(order, ShouldHaveLength, len(topics))
+	})
+	Convey("Invalid records return an error", t, func() {
+		_, _, _, err := parse([]byte("variable,topic,english,welsh\nsex,demography"))
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Missing topic names return an error", t, func() {
+		_, _, _, err := parse([]byte("variable,topic,english,welsh\nsex,demography,,Demograffeg a mudo"))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestForVariable(t *testing.T) {
+	Convey("Given a variable in the topic mapping", t, func() {
+		Convey("Then its topic is returned, ignoring the categorisation suffix", func() {
+			topic, ok := ForVariable("resident_age_101a")
+			So(ok, ShouldBeTrue)
+			So(topic.ID, ShouldEqual, "demography")
+			So(topic.Label("en"), ShouldEqual, "Demography and migration")
+			So(topic.Label("cy"), ShouldEqual, "Demograffeg a mudo")
+		})
+	})
+	Convey("Given a variable which is not in the topic mapping", t, func() {
+		Convey("Then no topic is returned", func() {
+			_, ok := ForVariable("unknown_variable")
+			So(ok, ShouldBeFalse)
+		})
+	})
+}
+
+func TestIndex(t *testing.T) {
+	Convey("Topics are ordered as they appear in the mapping", t, func() {
+		So(Index("demography"), ShouldBeLessThan, Index("housing"))
+		So(Index("housing"), ShouldBeLessThan, Index(Other))
+	})
+}
@@ -0,0 +1,151 @@
+package welsh
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/search"
+	"github.com/ONSdigital/log.go/v2/log"
+)
+
+const (
+	lang           = "cy"
+	minQueryLength = 3
+)
+
+// statisticalName matches the number of a statistical area, e.g. the "001A" of the lower layer super output area "Cardiff 001A",
+// statistical areas are named after their local authority so their Welsh names are derived from the Welsh local authority names
+var statisticalName = regexp.MustCompile(`^(.+) (\d{3}[A-Za-z]?)$`)
+
+// areaName represents the English and Welsh names of an area
+type areaName struct {
+	English string
+	Welsh   string
+}
+
+var (
+	once  sync.Once
+	names map[string]areaName
+	order []string
+)
+
+// Label returns the Welsh name of the area when the language is Welsh. Areas in England keep their English names, apart from
+// the nations and regions, and areas which are only labelled by their code are not translated. A Welsh area without a Welsh name
+// in the reference data is logged so the reference data can be completed.
+func Label(code, label, language string) string {
+	if language != lang || label == "" || strings.EqualFold(code, label) || !hasWelshName(code) {
+		return label
+	}
+	if welsh, ok := welshName(label); ok {
+		return welsh
+	}
+	log.Warn(context.Background(), "missing welsh area name", log.Data{"code": code, "label": label})
+	return label
+}
+
+// hasWelshName determines whether the area has a Welsh name, which are the areas in Wales, England and Wales
+// and the English nation and regions
+func hasWelshName(code string) bool {
+	code = strings.ToUpper(code)
+	for _, prefix := range []string{"W", "K04", "E92", "E12"} {
+		if strings.HasPrefix(code, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// welshName returns the Welsh name of the English name, the names of statistical areas are derived from their local authority
+func welshName(english string) (string, bool) {
+	load()
+	if name, ok := names[key(english)]; ok {
+		return name.Welsh, true
+	}
+	if m := statisticalName.FindStringSubmatch(english); m != nil {
+		if name, ok := names[key(m[1])]; ok {
+			return name.Welsh + " " + m[2], true
+		}
+	}
+	return "", false
+}
+
+// EnglishName returns the English name of the area whose Welsh name best matches the query.
+// Exact matches are preferred, followed by names which start with the query and then names which contain it.
+// Statistical areas are matched by the Welsh name of their local authority followed by their number.
+func EnglishName(query string) (string, bool) {
+	q := strings.Join(strings.Fields(search.Normalise(query)), " ")
+	if len([]rune(q)) < minQueryLength {
+		return "", false
+	}
+	load()
+	var prefix, contains string
+	for _, k := range order {
+		name := names[k]
+		welsh := search.Normalise(name.Welsh)
+		switch {
+		case welsh == search.Normalise(name.English):
+			// names which are the same in both languages are found by the population API
+			continue
+		case welsh == q:
+			return name.English, true
+		case strings.HasPrefix(q, welsh+" ") && statisticalName.MatchString(name.Welsh+q[len(welsh):]):
+			return name.English + strings.ToUpper(q[len(welsh):]), true
+		case prefix == "" && strings.HasPrefix(welsh, q):
+			prefix = name.English
+		case contains == "" && strings.Contains(welsh, q):
+			contains = name.English
+		}
+	}
+	if prefix != "" {
+		return prefix, true
+	}
+	return contains, contains != ""
+}
+
+// key normalises an English name for lookups
+func key(english string) string {
+	return strings.ToLower(strings.Join(strings.Fields(english), " "))
+}
+
+// load parses the embedded Welsh area names once
+func load() {
+	once.Do(func() {
+		var err error
+		names, order, err = parse(assets.WelshAreaNames)
+		if err != nil {
+			log.Error(context.Background(), "failed to parse welsh area names", err)
+		}
+	})
+}
+
+// parse reads a csv of English area names with their Welsh names, preserving the order of the names
+func parse(data []byte) (map[string]areaName, []string, error) {
+	records, err := csv.NewReader(bytes.NewReader(data)).ReadAll()
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil, errors.New("missing header")
+	}
+
+	parsed := make(map[string]areaName, len(records)-1)
+	var keys []string
+	for _, record := range records[1:] {
+		if len(record) != 2 || record[0] == "" || record[1] == "" {
+			return nil, nil, errors.New("invalid record: " + strings.Join(record, ","))
+		}
+		k := key(record[0])
+		if _, ok := parsed[k]; ok {
+			return nil, nil, errors.New("duplicate area name: " + record[0])
+		}
+		parsed[k] = areaName{English: record[0], Welsh: record[1]}
+		keys = append(keys, k)
+	}
+	return parsed, keys, nil
+}
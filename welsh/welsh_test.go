@@ -0,0 +1,93 @@
+package welsh
+
+import (
+	"testing"
+
+	"github.com/ONSdigital/dp-frontend-filter-flex-dataset/assets"
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestParse(t *testing.T) {
+	Convey("The embedded Welsh area names are valid", t, func() {
+		parsed, order, err := parse(assets.WelshAreaNames)
+		So(err, ShouldBeNil)
+		So(parsed, ShouldNotBeEmpty)
+		So(order, ShouldHaveLength, len(parsed))
+	})
+	Convey("Invalid records return an error", t, func() {
+		_, _, err := parse([]byte("english,welsh\nCardiff"))
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Missing Welsh names return an error", t, func() {
+		_, _, err := parse([]byte("english,welsh\nCardiff,"))
+		So(err, ShouldNotBeNil)
+	})
+	Convey("Duplicate names return an error", t, func() {
+		_, _, err := parse([]byte("english,welsh\nCardiff,Caerdydd\ncardiff,Caerdydd"))
+		So(err, ShouldNotBeNil)
+	})
+}
+
+func TestLabel(t *testing.T) {
+	Convey("Given the language is Welsh", t, func() {
+		Convey("Then the Welsh name is returned", func() {
+			So(Label("W06000015", "Cardiff", "cy"), ShouldEqual, "Caerdydd")
+			So(Label("w06000001", "Isle of Anglesey", "cy"), ShouldEqual, "Ynys Môn")
+			So(Label("W07000050", "Cardiff Central", "cy"), ShouldEqual, "Canol Caerdydd")
+		})
+		Convey("Then the nations and English regions have Welsh names", func() {
+			So(Label("K04000001", "England and Wales", "cy"), ShouldEqual, "Cymru a Lloegr")
+			So(Label("E92000001", "England", "cy"), ShouldEqual, "Lloegr")
+			So(Label("E12000007", "London", "cy"), ShouldEqual, "Llundain")
+		})
+		Convey("Then the names of statistical areas are derived from their local authority", func() {
+			So(Label("W02000384", "Cardiff 001", "cy"), ShouldEqual, "Caerdydd 001")
+			So(Label("W01001701", "Cardiff 001A", "cy"), ShouldEqual, "Caerdydd 001A")
+		})
+		Convey("Then areas in England keep their English names", func() {
+			So(Label("E08000003", "Manchester", "cy"), ShouldEqual, "Manchester")
+			So(Label("E05000001", "Newport", "cy"), ShouldEqual, "Newport")
+		})
+		Convey("Then areas labelled by their code are not translated", func() {
+			So(Label("W00000001", "W00000001", "cy"), ShouldEqual, "W00000001")
+		})
+	})
+	Convey("Given the language is English", t, func() {
+		Convey("Then the label is returned", func() {
+			So(Label("W06000015", "Cardiff", "en"), ShouldEqual, "Cardiff")
+		})
+	})
+}
+
+func TestEnglishName(t *testing.T) {
+	Convey("Welsh names are matched regardless of case and diacritics", t, func() {
+		name, ok := EnglishName("caerdydd")
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "Cardiff")
+
+		name, ok = EnglishName("Ynys Mon")
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "Isle of Anglesey")
+	})
+	Convey("Names starting with the query are preferred", t, func() {
+		name, ok := EnglishName("Cymru")
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "Wales")
+	})
+	Convey("Partial Welsh names are matched", t, func() {
+		name, ok := EnglishName("Morgannwg")
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "Vale of Glamorgan")
+	})
+	Convey("Statistical areas are matched by the Welsh name of their local authority", t, func() {
+		name, ok := EnglishName("Caerdydd 001a")
+		So(ok, ShouldBeTrue)
+		So(name, ShouldEqual, "Cardiff 001A")
+	})
+	Convey("English names and short queries are not matched", t, func() {
+		_, ok := EnglishName("Cardiff")
+		So(ok, ShouldBeFalse)
+		_, ok = EnglishName("ca")
+		So(ok, ShouldBeFalse)
+	})
+}